@@ -0,0 +1,108 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package orc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"time"
+)
+
+// ORC_DEFAULT_CACHE_TTL is the default time a cached orc response is considered fresh.
+const ORC_DEFAULT_CACHE_TTL = 24 * time.Hour
+
+// ErrNotCached is returned in offline mode if the response is not available in the cache.
+var ErrNotCached = errors.New("orc response is not cached")
+
+// WithCache caches the orc api responses in the directory.
+// Cached responses are reused until they are older than the ttl (0 means responses never expire).
+func WithCache(cachePath string, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cachePath = cachePath
+		c.cacheTTL = ttl
+	}
+}
+
+// WithOffline disables requests to the orc api, responses are only served from the cache (regardless of their age).
+func WithOffline(offline bool) Option {
+	return func(c *Client) {
+		c.offline = offline
+	}
+}
+
+// cacheFile returns the cache file of the query.
+// Cache entries are addressed by the hash of the endpoint and query, so that responses of different
+// endpoints (e.g. a local stand-in server) never mix.
+func (c *Client) cacheFile(query url.Values) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s?%s", c.baseURL, query.Encode())))
+	return path.Join(c.cachePath, hex.EncodeToString(hash[:])+".json")
+}
+
+// readCache reads the cached response of the query.
+// Returns nil if the query is not cached, expired entries are only returned in offline mode.
+func (c *Client) readCache(query url.Values) ([]byte, error) {
+	cacheFile := c.cacheFile(query)
+	cacheInfo, err := os.Stat(cacheFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading orc cache failed: %w", err)
+	}
+	if !c.offline && c.cacheTTL > 0 && time.Since(cacheInfo.ModTime()) > c.cacheTTL {
+		return nil, nil
+	}
+	raw, err := os.ReadFile(cacheFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading orc cache failed: %w", err)
+	}
+	return raw, nil
+}
+
+// writeCache stores the response of the query in the cache.
+// The entry is written to a temporary file first, so that concurrent runs never read partial entries.
+func (c *Client) writeCache(query url.Values, raw []byte) error {
+	err := os.MkdirAll(c.cachePath, 0755)
+	if err != nil {
+		return fmt.Errorf("writing orc cache failed: %w", err)
+	}
+	cacheFile, err := os.CreateTemp(c.cachePath, ".entry-*")
+	if err != nil {
+		return fmt.Errorf("writing orc cache failed: %w", err)
+	}
+	defer os.Remove(cacheFile.Name())
+
+	_, err = cacheFile.Write(raw)
+	if closeErr := cacheFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("writing orc cache failed: %w", err)
+	}
+	err = os.Rename(cacheFile.Name(), c.cacheFile(query))
+	if err != nil {
+		return fmt.Errorf("writing orc cache failed: %w", err)
+	}
+	return nil
+}
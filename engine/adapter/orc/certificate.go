@@ -0,0 +1,95 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package orc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ORC_DEFAULT_CERT_MAX_AGE is the default age after which a certificate is considered expired.
+const ORC_DEFAULT_CERT_MAX_AGE = 365 * 24 * time.Hour
+
+// ORC_ISSUE_DATE_LAYOUTS are the layouts the certificate issue date is parsed with.
+var ORC_ISSUE_DATE_LAYOUTS = []string{
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+var (
+	ErrCertificateNotFound  = errors.New("orc certificate not found")
+	ErrAmbiguousCertificate = errors.New("orc certificate is ambiguous")
+	ErrUnexpectedFamily     = errors.New("unexpected orc certificate family")
+	ErrExpiredCertificate   = errors.New("orc certificate is expired")
+)
+
+// Certificate returns the certificate of the RefNo.
+// Fails if the response contains no certificate or multiple certificates, instead of guessing one of them.
+func (d *DownBoatRMS) Certificate(refNo string) (*RMS, error) {
+	switch len(d.Rms) {
+	case 0:
+		return nil, fmt.Errorf("%w: ship with RefNo. '%s' was not found on orc database", ErrCertificateNotFound, refNo)
+	case 1:
+		return &d.Rms[0], nil
+	default:
+		certNos := []string{}
+		for _, rms := range d.Rms {
+			certNos = append(certNos, rms.CertNo)
+		}
+		return nil, fmt.Errorf("%w: RefNo. '%s' returned %d certificates (CertNo %q)", ErrAmbiguousCertificate, refNo, len(d.Rms), certNos)
+	}
+}
+
+// ParseIssueDate parses the issue date of the certificate.
+func (r *RMS) ParseIssueDate() (time.Time, error) {
+	for _, layout := range ORC_ISSUE_DATE_LAYOUTS {
+		issueDate, err := time.Parse(layout, r.IssueDate)
+		if err == nil {
+			return issueDate, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid orc certificate issue date '%s' (RefNo. '%s')", r.IssueDate, r.RefNo)
+}
+
+// CheckFamily fails if the certificate family is not one of the families.
+func (r *RMS) CheckFamily(families map[string]struct{}) error {
+	if _, ok := families[r.Family]; !ok {
+		return fmt.Errorf("%w '%s' (RefNo. '%s', certificate type '%s')", ErrUnexpectedFamily, r.Family, r.RefNo, r.C_Type)
+	}
+	return nil
+}
+
+// CheckAge fails if the certificate was issued more than maxAge before now (0 disables the check).
+func (r *RMS) CheckAge(maxAge time.Duration, now time.Time) error {
+	if maxAge <= 0 {
+		return nil
+	}
+	issueDate, err := r.ParseIssueDate()
+	if err != nil {
+		return err
+	}
+	if now.Sub(issueDate) > maxAge {
+		return fmt.Errorf("%w: RefNo. '%s' was issued on '%s'", ErrExpiredCertificate, r.RefNo, r.IssueDate)
+	}
+	return nil
+}
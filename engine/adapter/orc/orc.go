@@ -21,42 +21,99 @@ package orc
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 )
 
-const ORC_BASE_ENDPOINT = "https://data.orc.org/public/WPub.dll"
+const (
+	ORC_BASE_ENDPOINT      = "https://data.orc.org/public/WPub.dll"
+	ORC_DEFAULT_TIMEOUT    = 30 * time.Second
+	ORC_DEFAULT_USER_AGENT = "opensail-engine"
+)
+
+// ORC_BOM is the utf-8 byte order mark the orc api prefixes its json responses with.
+const ORC_BOM = "\xef\xbb\xbf"
 
+// CERT_FAMILIES are the orc certificate families accepted by default.
 var CERT_FAMILIES = map[string]struct{}{
 	"ORC": {},
 	"DH":  {},
 	"NS":  {},
 }
 
+// Client executes actions on the orc api.
+type Client struct {
+	baseURL    string
+	timeout    time.Duration
+	userAgent  string
+	httpClient *http.Client
+
+	cachePath string
+	cacheTTL  time.Duration
+	offline   bool
+}
+
+// Option configures the orc client.
+type Option func(*Client)
+
+// WithBaseURL replaces the orc api endpoint (e.g. with a local stand-in server).
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithTimeout specifies the timeout of a single orc api request (0 disables the timeout).
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithUserAgent specifies the user agent sent to the orc api.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithHTTPClient replaces the underlying http client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient creates an orc api client, by default querying the public orc api.
+func NewClient(opts ...Option) *Client {
+	client := &Client{
+		baseURL:    ORC_BASE_ENDPOINT,
+		timeout:    ORC_DEFAULT_TIMEOUT,
+		userAgent:  ORC_DEFAULT_USER_AGENT,
+		httpClient: &http.Client{},
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
+}
+
 // GetDownBoatRMS executes the DownBoatRMS action on the orc api, querying by orc cert ref number.
-func GetDownBoatRMS(refNo string) (*DownBoatRMS, error) {
+func (c *Client) GetDownBoatRMS(ctx context.Context, refNo string) (*DownBoatRMS, error) {
 	orcQuery := url.Values{}
 	orcQuery.Add("action", "DownBoatRMS")
 	orcQuery.Add("RefNo", refNo)
 	orcQuery.Add("ext", "json")
 
-	client := &http.Client{}
-	resp, err := client.Get(fmt.Sprintf("%s?%s", ORC_BASE_ENDPOINT, orcQuery.Encode()))
+	downBoatRmsRaw, err := c.get(ctx, orcQuery)
 	if err != nil {
-		return nil, fmt.Errorf("fetching orc data failed: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	downBoatRmsRaw, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("reading orc data failed: %w", err)
-	}
-
-	// Remove retarded BOM header that the orc api is using for whatever reason.
-	downBoatRmsRaw = bytes.TrimPrefix(downBoatRmsRaw, []byte("\xef\xbb\xbf"))
 
 	downBoatRms := &DownBoatRMS{}
 	err = json.Unmarshal(downBoatRmsRaw, downBoatRms)
@@ -66,3 +123,66 @@ func GetDownBoatRMS(refNo string) (*DownBoatRMS, error) {
 
 	return downBoatRms, nil
 }
+
+// get executes the query on the orc api and returns the raw response without BOM.
+// If a cache is configured, cached responses are served instead of querying the orc api.
+func (c *Client) get(ctx context.Context, query url.Values) ([]byte, error) {
+	if c.cachePath != "" {
+		raw, err := c.readCache(query)
+		if err != nil {
+			return nil, err
+		}
+		if raw != nil {
+			return raw, nil
+		}
+	}
+	if c.offline {
+		return nil, fmt.Errorf("%w (query '%s')", ErrNotCached, query.Encode())
+	}
+
+	raw, err := c.fetch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.cachePath != "" {
+		err = c.writeCache(query, raw)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return raw, nil
+}
+
+// fetch executes the query on the orc api.
+func (c *Client) fetch(ctx context.Context, query url.Values) ([]byte, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?%s", c.baseURL, query.Encode()), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating orc request failed: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching orc data failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching orc data failed: unexpected status '%s'", resp.Status)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading orc data failed: %w", err)
+	}
+
+	// Remove retarded BOM header that the orc api is using for whatever reason.
+	return bytes.TrimPrefix(raw, []byte(ORC_BOM)), nil
+}
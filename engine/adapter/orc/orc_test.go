@@ -0,0 +1,204 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package orc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/megakuul/opensail/engine/adapter/orc"
+	"github.com/megakuul/opensail/engine/adapter/orc/orctest"
+)
+
+// testdata contains the recorded DownBoatRMS responses of the orc ships in the register.
+const TEST_FIXTURE_PATH = "testdata"
+
+func TestGetDownBoatRMS(t *testing.T) {
+	server := orctest.NewServer(TEST_FIXTURE_PATH)
+	defer server.Close()
+
+	client := server.Client(orc.WithUserAgent("opensail-test"))
+	downBoatRms, err := client.GetDownBoatRMS(context.Background(), "0308000349K")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(downBoatRms.Rms) != 1 || downBoatRms.Rms[0].YachtName != "BALLYHOO" || downBoatRms.Rms[0].LOA != 11 {
+		t.Fatalf("unexpected response %+v", downBoatRms)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 || requests[0].Get("RefNo") != "0308000349K" || requests[0].Get("ext") != "json" {
+		t.Fatalf("unexpected requests %v", requests)
+	}
+}
+
+func TestGetDownBoatRMSUnknownRefNo(t *testing.T) {
+	server := orctest.NewServer(TEST_FIXTURE_PATH)
+	defer server.Close()
+
+	for _, refNo := range []string{"UNKNOWN", "../testdata/0308000349K"} {
+		downBoatRms, err := server.Client().GetDownBoatRMS(context.Background(), refNo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(downBoatRms.Rms) != 0 {
+			t.Fatalf("expected no certificate for RefNo. '%s', got %+v", refNo, downBoatRms)
+		}
+	}
+}
+
+func TestClientOptions(t *testing.T) {
+	userAgent := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent <- r.UserAgent()
+		if r.URL.Query().Get("RefNo") == "SLOW" {
+			<-r.Context().Done()
+			return
+		}
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := orc.NewClient(
+		orc.WithBaseURL(server.URL),
+		orc.WithTimeout(50*time.Millisecond),
+		orc.WithUserAgent("opensail-test"),
+	)
+
+	if _, err := client.GetDownBoatRMS(context.Background(), "0308000349K"); err == nil {
+		t.Fatal("expected an error for a non-200 response")
+	}
+	if agent := <-userAgent; agent != "opensail-test" {
+		t.Fatalf("unexpected user agent '%s'", agent)
+	}
+
+	_, err := client.GetDownBoatRMS(context.Background(), "SLOW")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the request to time out, got %v", err)
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	server := orctest.NewServer(TEST_FIXTURE_PATH)
+	defer server.Close()
+	cachePath := t.TempDir()
+
+	client := server.Client(orc.WithCache(cachePath, time.Hour))
+	for i := 0; i < 2; i++ {
+		downBoatRms, err := client.GetDownBoatRMS(context.Background(), "0308000349K")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(downBoatRms.Rms) != 1 {
+			t.Fatalf("unexpected response %+v", downBoatRms)
+		}
+	}
+	if requests := server.Requests(); len(requests) != 1 {
+		t.Fatalf("expected the second lookup to be served from the cache, got %d requests", len(requests))
+	}
+
+	offlineClient := server.Client(orc.WithCache(cachePath, time.Nanosecond), orc.WithOffline(true))
+	if _, err := offlineClient.GetDownBoatRMS(context.Background(), "0308000349K"); err != nil {
+		t.Fatalf("expected expired entries to be served in offline mode, got %v", err)
+	}
+	if _, err := offlineClient.GetDownBoatRMS(context.Background(), "UNKNOWN"); !errors.Is(err, orc.ErrNotCached) {
+		t.Fatalf("expected uncached RefNo to fail in offline mode, got %v", err)
+	}
+	if requests := server.Requests(); len(requests) != 1 {
+		t.Fatalf("expected no requests in offline mode, got %d requests", len(requests)-1)
+	}
+}
+
+func TestCertificate(t *testing.T) {
+	if _, err := (&orc.DownBoatRMS{}).Certificate("0308000349K"); !errors.Is(err, orc.ErrCertificateNotFound) {
+		t.Fatalf("expected missing certificate, got %v", err)
+	}
+	ambiguous := &orc.DownBoatRMS{Rms: []orc.RMS{{CertNo: "A"}, {CertNo: "B"}}}
+	if _, err := ambiguous.Certificate("0308000349K"); !errors.Is(err, orc.ErrAmbiguousCertificate) {
+		t.Fatalf("expected ambiguous certificate, got %v", err)
+	}
+
+	certificate := &orc.RMS{RefNo: "0308000349K", Family: "ORC", IssueDate: "2024-03-01T10:00:00"}
+	if err := certificate.CheckFamily(orc.CERT_FAMILIES); err != nil {
+		t.Fatal(err)
+	}
+	if err := certificate.CheckFamily(map[string]struct{}{"DH": {}}); !errors.Is(err, orc.ErrUnexpectedFamily) {
+		t.Fatalf("expected unexpected family, got %v", err)
+	}
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if err := certificate.CheckAge(orc.ORC_DEFAULT_CERT_MAX_AGE, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := certificate.CheckAge(orc.ORC_DEFAULT_CERT_MAX_AGE, now.AddDate(2, 0, 0)); !errors.Is(err, orc.ErrExpiredCertificate) {
+		t.Fatalf("expected expired certificate, got %v", err)
+	}
+	if err := certificate.CheckAge(0, now.AddDate(2, 0, 0)); err != nil {
+		t.Fatalf("expected disabled age check, got %v", err)
+	}
+	if err := (&orc.RMS{IssueDate: "01.03.2024"}).CheckAge(orc.ORC_DEFAULT_CERT_MAX_AGE, now); err == nil {
+		t.Fatal("expected invalid issue date to fail")
+	}
+}
+
+func TestSearchQueryMatches(t *testing.T) {
+	rms := &orc.RMS{SailNo: "SUI 1234", YachtName: "BALLYHOO", NatAuth: "SUI"}
+
+	tests := []struct {
+		name    string
+		query   orc.SearchQuery
+		matches bool
+	}{
+		{"sail_no", orc.SearchQuery{SailNo: "sui-1234"}, true},
+		{"sail_no_part", orc.SearchQuery{SailNo: "1234"}, true},
+		{"yacht_name", orc.SearchQuery{YachtName: "ballyhoo"}, true},
+		{"nat_auth", orc.SearchQuery{NatAuth: "sui", YachtName: "BALLY"}, true},
+		{"other_sail_no", orc.SearchQuery{SailNo: "SUI 4321"}, false},
+		{"other_nat_auth", orc.SearchQuery{SailNo: "SUI 1234", NatAuth: "GER"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if matches := test.query.Matches(rms); matches != test.matches {
+				t.Fatalf("expected match '%t', got '%t'", test.matches, matches)
+			}
+		})
+	}
+}
+
+func TestSearchRMS(t *testing.T) {
+	server := orctest.NewServer(TEST_FIXTURE_PATH)
+	defer server.Close()
+
+	downBoatRms, err := server.Client().SearchRMS(context.Background(), orc.SearchQuery{YachtName: "ballyhoo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(downBoatRms.Rms) != 1 || downBoatRms.Rms[0].RefNo != "0308000349K" {
+		t.Fatalf("unexpected result %+v", downBoatRms)
+	}
+
+	if _, err := server.Client().SearchRMS(context.Background(), orc.SearchQuery{}); err == nil {
+		t.Fatal("expected an empty query to fail")
+	}
+}
@@ -0,0 +1,164 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package orctest provides a local stand-in for the orc api, used to test the engine offline.
+package orctest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/megakuul/opensail/engine/adapter/orc"
+)
+
+// ORCTEST_PATH is the path of the api endpoint, matching the path of the orc api.
+const ORCTEST_PATH = "/public/WPub.dll"
+
+// Server serves recorded orc api responses.
+// DownBoatRMS responses are read from the fixture directory ('<RefNo>.json') and prefixed with the BOM
+// the orc api sends. Unknown RefNos are answered with an empty rms list, as the orc api does. DownBoatRMS
+// requests without RefNo are answered with the certificates of all fixtures matching the search parameters.
+type Server struct {
+	*httptest.Server
+
+	fixturePath string
+
+	requestsLock sync.Mutex
+	requests     []url.Values
+}
+
+// NewServer starts a server serving the fixtures of the directory. The server must be closed by the caller.
+func NewServer(fixturePath string) *Server {
+	server := &Server{fixturePath: fixturePath}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+	return server
+}
+
+// Endpoint returns the api endpoint of the server (used with orc.WithBaseURL).
+func (s *Server) Endpoint() string {
+	return s.URL + ORCTEST_PATH
+}
+
+// Client returns an orc client querying the server.
+func (s *Server) Client(opts ...orc.Option) *orc.Client {
+	return orc.NewClient(append([]orc.Option{orc.WithBaseURL(s.Endpoint())}, opts...)...)
+}
+
+// Requests returns the queries the server received.
+func (s *Server) Requests() []url.Values {
+	s.requestsLock.Lock()
+	defer s.requestsLock.Unlock()
+	return append([]url.Values{}, s.requests...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != ORCTEST_PATH {
+		http.NotFound(w, r)
+		return
+	}
+	query := r.URL.Query()
+	s.requestsLock.Lock()
+	s.requests = append(s.requests, query)
+	s.requestsLock.Unlock()
+
+	switch query.Get("action") {
+	case "DownBoatRMS":
+		if query.Has("RefNo") {
+			s.serveFixture(w, query.Get("RefNo"))
+		} else {
+			s.serveSearch(w, orc.SearchQuery{
+				SailNo:    query.Get("SailNo"),
+				YachtName: query.Get("YachtName"),
+				NatAuth:   query.Get("CountryId"),
+			})
+		}
+	default:
+		http.Error(w, "unknown action", http.StatusBadRequest)
+	}
+}
+
+// serveFixture writes the recorded response of the fixture with the BOM prefix.
+func (s *Server) serveFixture(w http.ResponseWriter, name string) {
+	// RefNos are plain identifiers, anything that could escape the fixture directory is unknown.
+	if name == "" || path.Base(name) != name || name == ".." {
+		s.serve(w, []byte(`{"rms":[]}`))
+		return
+	}
+	fixtureRaw, err := os.ReadFile(path.Join(s.fixturePath, name+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		s.serve(w, []byte(`{"rms":[]}`))
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.serve(w, fixtureRaw)
+}
+
+// serveSearch writes the certificates of all fixtures matching the query.
+func (s *Server) serveSearch(w http.ResponseWriter, query orc.SearchQuery) {
+	fixtures, err := os.ReadDir(s.fixturePath)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	matches := orc.DownBoatRMS{Rms: []orc.RMS{}}
+	for _, fixture := range fixtures {
+		if fixture.IsDir() || path.Ext(fixture.Name()) != ".json" {
+			continue
+		}
+		fixtureRaw, err := os.ReadFile(path.Join(s.fixturePath, fixture.Name()))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		downBoatRms := orc.DownBoatRMS{}
+		err = json.Unmarshal(bytes.TrimPrefix(fixtureRaw, []byte(orc.ORC_BOM)), &downBoatRms)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, rms := range downBoatRms.Rms {
+			if query.Matches(&rms) {
+				matches.Rms = append(matches.Rms, rms)
+			}
+		}
+	}
+	matchesRaw, err := json.Marshal(matches)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.serve(w, matchesRaw)
+}
+
+func (s *Server) serve(w http.ResponseWriter, raw []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	if !bytes.HasPrefix(raw, []byte(orc.ORC_BOM)) {
+		w.Write([]byte(orc.ORC_BOM))
+	}
+	w.Write(raw)
+}
@@ -0,0 +1,98 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package orc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SearchQuery specifies the filters of a certificate search, empty filters are ignored.
+type SearchQuery struct {
+	// SailNo specifies the sail number (e.g. 'SUI 123'), spaces and dashes are ignored.
+	SailNo string
+	// YachtName specifies a part of the yacht name, the case is ignored.
+	YachtName string
+	// NatAuth specifies the national authority issuing the certificate (e.g. 'SUI').
+	NatAuth string
+}
+
+// Matches returns whether the certificate matches the query.
+func (q SearchQuery) Matches(rms *RMS) bool {
+	if q.SailNo != "" && !strings.Contains(normalizeSailNo(rms.SailNo), normalizeSailNo(q.SailNo)) {
+		return false
+	}
+	if q.YachtName != "" && !strings.Contains(strings.ToUpper(rms.YachtName), strings.ToUpper(q.YachtName)) {
+		return false
+	}
+	if q.NatAuth != "" && !strings.EqualFold(rms.NatAuth, q.NatAuth) {
+		return false
+	}
+	return true
+}
+
+// normalizeSailNo removes the formatting of the sail number ('sui-123' -> 'SUI123').
+func normalizeSailNo(sailNo string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.ToUpper(sailNo))
+}
+
+// SearchRMS executes the DownBoatRMS action on the orc api, querying by sail number, yacht name and national authority.
+// The api matches loosely, therefore the certificates are filtered by the query again.
+func (c *Client) SearchRMS(ctx context.Context, query SearchQuery) (*DownBoatRMS, error) {
+	if query.SailNo == "" && query.YachtName == "" && query.NatAuth == "" {
+		return nil, errors.New("orc search requires a sail number, yacht name or national authority")
+	}
+
+	orcQuery := url.Values{}
+	orcQuery.Add("action", "DownBoatRMS")
+	if query.SailNo != "" {
+		orcQuery.Add("SailNo", query.SailNo)
+	}
+	if query.YachtName != "" {
+		orcQuery.Add("YachtName", query.YachtName)
+	}
+	if query.NatAuth != "" {
+		orcQuery.Add("CountryId", strings.ToUpper(query.NatAuth))
+	}
+	orcQuery.Add("ext", "json")
+
+	downBoatRmsRaw, err := c.get(ctx, orcQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	downBoatRms := &DownBoatRMS{}
+	err = json.Unmarshal(downBoatRmsRaw, downBoatRms)
+	if err != nil {
+		return nil, fmt.Errorf("parsing orc data failed: %w", err)
+	}
+
+	matches := &DownBoatRMS{Rms: []RMS{}}
+	for _, rms := range downBoatRms.Rms {
+		if query.Matches(&rms) {
+			matches.Rms = append(matches.Rms, rms)
+		}
+	}
+	return matches, nil
+}
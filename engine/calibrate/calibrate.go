@@ -0,0 +1,171 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package calibrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/BurntSushi/toml"
+	"github.com/megakuul/opensail/engine/generate"
+	"github.com/megakuul/opensail/engine/structure/input"
+	"github.com/megakuul/opensail/engine/structure/output"
+	"github.com/megakuul/opensail/openfactor"
+	"github.com/spf13/cobra"
+)
+
+type calibrateFlags struct {
+	inputPath          string
+	datasetPath        string
+	calibrationPath    string
+	outputPath         string
+	calibrationVersion string
+
+	orc generate.ORCFlags
+}
+
+// dataset specifies the toml representation of the race results.
+type dataset struct {
+	Races []struct {
+		Name    string `toml:"name"`
+		Results []struct {
+			Ship        string  `toml:"ship"`
+			ElapsedTime float64 `toml:"elapsed_time"`
+		} `toml:"result"`
+	} `toml:"race"`
+}
+
+func NewCalibrateCmd(inputStruct *input.Structure, outputStruct *output.Structure) *cobra.Command {
+	flags := &calibrateFlags{}
+
+	cmd := &cobra.Command{
+		Use:          "calibrate",
+		Short:        "fit the openfactor calibration to race results and write the proposed calibration",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return Run(cmd.Context(), cmd.OutOrStdout(), flags, inputStruct, outputStruct)
+		},
+	}
+
+	cmd.Flags().SortFlags = false
+	cmd.Flags().StringVarP(&flags.inputPath, "input-path", "i",
+		".", "specify the repository base path",
+	)
+	cmd.Flags().StringVarP(&flags.datasetPath, "dataset", "d",
+		"", "specify the toml dataset with the elapsed times (in seconds) per race and ship",
+	)
+	cmd.Flags().StringVar(&flags.calibrationPath, "calibration",
+		"", "specify a toml calibration file used as starting point instead of the built-in openfactor calibration",
+	)
+	cmd.Flags().StringVarP(&flags.outputPath, "output-path", "o",
+		"./calibration.toml", "specify the path of the proposed calibration file",
+	)
+	cmd.Flags().StringVar(&flags.calibrationVersion, "calibration-version",
+		"", "specify the version of the proposed calibration (defaults to '<base version>-calibrated')",
+	)
+	cmd.MarkFlagRequired("dataset")
+
+	generate.AddORCFlags(cmd.Flags(), &flags.orc)
+
+	return cmd
+}
+
+func Run(ctx context.Context, w io.Writer, flags *calibrateFlags, inputStruct *input.Structure, outputStruct *output.Structure) error {
+	calibration, err := generate.LoadCalibration(flags.calibrationPath)
+	if err != nil {
+		return err
+	}
+	orcClient, err := generate.NewORCClient(&flags.orc)
+	if err != nil {
+		return err
+	}
+
+	datasetRaw, err := os.ReadFile(flags.datasetPath)
+	if err != nil {
+		return err
+	}
+	raceDataset := &dataset{}
+	if err := toml.Unmarshal(datasetRaw, raceDataset); err != nil {
+		return fmt.Errorf("failed to parse dataset: %w", err)
+	}
+
+	factorInputs := map[string]*openfactor.EvaluationInput{}
+	races := []openfactor.Race{}
+	for _, datasetRace := range raceDataset.Races {
+		race := openfactor.Race{Name: datasetRace.Name}
+		for _, datasetResult := range datasetRace.Results {
+			factorInput, ok := factorInputs[datasetResult.Ship]
+			if !ok {
+				factorInput, err = generate.GenerateShipFactorInput(ctx, orcClient, flags.inputPath, datasetResult.Ship, inputStruct.Ship)
+				if err != nil {
+					return err
+				}
+				factorInputs[datasetResult.Ship] = factorInput
+			}
+			race.Results = append(race.Results, openfactor.RaceResult{
+				Ship:        datasetResult.Ship,
+				Input:       factorInput,
+				ElapsedTime: datasetResult.ElapsedTime,
+			})
+		}
+		races = append(races, race)
+	}
+
+	result, err := openfactor.Calibrate(races, calibration)
+	if err != nil {
+		return err
+	}
+	result.Calibration.Version = flags.calibrationVersion
+	if result.Calibration.Version == "" {
+		result.Calibration.Version = calibration.Version + "-calibrated"
+	}
+
+	calibrationRaw, err := openfactor.EncodeCalibration(result.Calibration)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(flags.outputPath, calibrationRaw, 0644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "calibrated %d results in %d races (openfactor %s -> %s)\n\n",
+		result.After.Results, result.After.Races, calibration.Version, result.Calibration.Version,
+	)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "statistic\tbefore\tafter\t")
+	fmt.Fprintf(table, "mean spread %%\t%.3f\t%.3f\t\n", result.Before.MeanSpread, result.After.MeanSpread)
+	fmt.Fprintf(table, "max spread %%\t%.3f\t%.3f\t\n", result.Before.MaxSpread, result.After.MaxSpread)
+	fmt.Fprintf(table, "mean deviation %%\t%.3f\t%.3f\t\n", result.Before.MeanDeviation, result.After.MeanDeviation)
+	fmt.Fprintln(table, "\t\t\t")
+	fmt.Fprintln(table, "constant\tbefore\tafter\t")
+	for _, change := range result.Changes {
+		fmt.Fprintf(table, "%s\t%.4f\t%.4f\t\n", change.Name, change.Before, change.After)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nproposed calibration written to '%s'\n", flags.outputPath)
+	return nil
+}
@@ -0,0 +1,141 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package compare
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/megakuul/opensail/engine/generate"
+	"github.com/megakuul/opensail/engine/structure/input"
+	"github.com/megakuul/opensail/engine/structure/output"
+	"github.com/megakuul/opensail/openfactor"
+	"github.com/spf13/cobra"
+)
+
+type compareFlags struct {
+	inputPath   string
+	fromVersion string
+	toVersion   string
+
+	orc generate.ORCFlags
+}
+
+func NewCompareCmd(inputStruct *input.Structure, outputStruct *output.Structure) *cobra.Command {
+	flags := &compareFlags{}
+
+	cmd := &cobra.Command{
+		Use:          "compare",
+		Short:        "print the tcc delta of every registered ship between two openfactor versions",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return Run(cmd.Context(), cmd.OutOrStdout(), flags, inputStruct, outputStruct)
+		},
+	}
+
+	cmd.Flags().SortFlags = false
+	cmd.Flags().StringVarP(&flags.inputPath, "input-path", "i",
+		".", "specify the repository base path",
+	)
+	cmd.Flags().StringVar(&flags.fromVersion, "from",
+		openfactor.Version(), "specify the openfactor version currently used by the register",
+	)
+	cmd.Flags().StringVar(&flags.toVersion, "to",
+		openfactor.VERSION_V2, "specify the openfactor version the register is migrated to",
+	)
+
+	generate.AddORCFlags(cmd.Flags(), &flags.orc)
+
+	return cmd
+}
+
+func Run(ctx context.Context, w io.Writer, flags *compareFlags, inputStruct *input.Structure, outputStruct *output.Structure) error {
+	fromAlgorithm, err := openfactor.LookupAlgorithm(flags.fromVersion)
+	if err != nil {
+		return err
+	}
+	toAlgorithm, err := openfactor.LookupAlgorithm(flags.toVersion)
+	if err != nil {
+		return err
+	}
+	orcClient, err := generate.NewORCClient(&flags.orc)
+	if err != nil {
+		return err
+	}
+
+	shipsDirectory, err := os.ReadDir(path.Join(flags.inputPath, inputStruct.Ship.BasePath))
+	if err != nil {
+		return err
+	}
+	ships := []string{}
+	for _, entry := range shipsDirectory {
+		if entry.IsDir() {
+			ships = append(ships, entry.Name())
+		}
+	}
+	sort.Strings(ships)
+
+	fmt.Fprintf(w, "openfactor %s -> %s\n\n", fromAlgorithm.Version(), toAlgorithm.Version())
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(table, "ship\t%s tcc\t%s tcc\tdelta\tdelta %%\t\n", fromAlgorithm.Version(), toAlgorithm.Version())
+
+	totalDelta, maxDelta, maxDeltaShip := 0.0, 0.0, ""
+	for _, ship := range ships {
+		factorInput, err := generate.GenerateShipFactorInput(ctx, orcClient, flags.inputPath, ship, inputStruct.Ship)
+		if err != nil {
+			return err
+		}
+		fromOutput, err := fromAlgorithm.Evaluate(factorInput)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate ship '%s' with openfactor '%s': %w", ship, fromAlgorithm.Version(), err)
+		}
+		toOutput, err := toAlgorithm.Evaluate(factorInput)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate ship '%s' with openfactor '%s': %w", ship, toAlgorithm.Version(), err)
+		}
+
+		delta := toOutput.TCC - fromOutput.TCC
+		relativeDelta := delta / fromOutput.TCC * 100
+		totalDelta += math.Abs(relativeDelta)
+		if math.Abs(relativeDelta) >= math.Abs(maxDelta) {
+			maxDelta, maxDeltaShip = relativeDelta, ship
+		}
+
+		fmt.Fprintf(table, "%s\t%.4f\t%.4f\t%+.4f\t%+.2f\t\n",
+			ship, fromOutput.TCC, toOutput.TCC, delta, relativeDelta,
+		)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	if len(ships) > 0 {
+		fmt.Fprintf(w, "\nmean absolute delta: %.2f%%\n", totalDelta/float64(len(ships)))
+		fmt.Fprintf(w, "max delta: %+.2f%% (ship '%s')\n", maxDelta, maxDeltaShip)
+	}
+	return nil
+}
@@ -22,7 +22,11 @@ package main
 import (
 	"os"
 
+	"github.com/megakuul/opensail/engine/calibrate"
+	"github.com/megakuul/opensail/engine/compare"
 	"github.com/megakuul/opensail/engine/generate"
+	"github.com/megakuul/opensail/engine/orccmd"
+	"github.com/megakuul/opensail/engine/sensitivity"
 	"github.com/megakuul/opensail/engine/structure/input"
 	"github.com/megakuul/opensail/engine/structure/output"
 	"github.com/megakuul/opensail/engine/validate"
@@ -43,6 +47,8 @@ func main() {
 			InfoFile:      "info.toml",
 			BaseSpecFile:  "base_spec.toml",
 			ExtraSpecFile: "extra_spec.toml",
+			SailsFile:     "sails.toml",
+			ORCFile:       "orc.json",
 		},
 	}, &output.Structure{
 		Manifest: output.ManifestStructure{
@@ -72,6 +78,10 @@ func NewEngineCmd(inputStruct *input.Structure, outputStruct *output.Structure)
 
 	cmd.AddCommand(generate.NewGenerateCmd(inputStruct, outputStruct))
 	cmd.AddCommand(validate.NewValidateCmd(inputStruct, outputStruct))
+	cmd.AddCommand(sensitivity.NewSensitivityCmd(inputStruct, outputStruct))
+	cmd.AddCommand(compare.NewCompareCmd(inputStruct, outputStruct))
+	cmd.AddCommand(calibrate.NewCalibrateCmd(inputStruct, outputStruct))
+	cmd.AddCommand(orccmd.NewORCCmd(inputStruct, outputStruct))
 
 	return cmd
 }
@@ -0,0 +1,146 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package generate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/megakuul/opensail/engine/structure/output"
+	"github.com/megakuul/opensail/openfactor"
+)
+
+const (
+	CONFIDENCE_MANUAL_SOURCE_PENALTY = 0.25 // specs typed in by the owner are not backed by a measurement certificate
+	CONFIDENCE_MISSING_PENALTY       = 0.05 // optional field is not specified and rated with the neutral default
+	CONFIDENCE_OUTLIER_PENALTY       = 0.15 // derived ratio is outside of the range of common ships
+	CONFIDENCE_REVIEW_THRESHOLD      = 0.7  // ratings below this score are flagged for review
+)
+
+// confidenceOutlier specifies a ratio of the ship that is expected inside of the range of common ships.
+type confidenceOutlier struct {
+	name  string
+	value func(input *openfactor.EvaluationInput) float64
+	min   float64
+	max   float64
+}
+
+var CONFIDENCE_OUTLIERS = []confidenceOutlier{
+	{
+		// sail area / displacement ratio (sail area / displacement volume^2/3), from cruisers to foiling multihulls.
+		name: "sail_area_displacement_ratio",
+		value: func(input *openfactor.EvaluationInput) float64 {
+			return (input.MainSailArea + input.JibSailArea) / math.Pow(input.Displacement/1025, 2.0/3)
+		},
+		min: 8,
+		max: 100,
+	},
+	{
+		// displacement / length ratio (long tons / (loa in feet / 100)^3), from light multihulls to heavy cruisers.
+		name: "displacement_length_ratio",
+		value: func(input *openfactor.EvaluationInput) float64 {
+			return (input.Displacement / 1016) / math.Pow(input.LOA*3.2808/100, 3)
+		},
+		min: 10,
+		max: 500,
+	},
+	{
+		// beam / loa ratio, from narrow monohulls to wide multihulls.
+		name: "beam_loa_ratio",
+		value: func(input *openfactor.EvaluationInput) float64 {
+			return input.MaxBeam / input.LOA
+		},
+		min: 0.15,
+		max: 0.95,
+	},
+	{
+		// crew weight / displacement ratio, from large yachts to dinghies where the crew outweighs the hull.
+		name: "crew_displacement_ratio",
+		value: func(input *openfactor.EvaluationInput) float64 {
+			return input.CrewWeight / input.Displacement
+		},
+		min: 0.01,
+		max: 2,
+	},
+}
+
+// generateShipConfidence derives how much the rating of the ship can be trusted.
+// The score starts at 1 and is reduced by every finding (data provenance, missing optional fields and outliers).
+func generateShipConfidence(baseSpec *output.ShipConfigBaseSpec, factorInput *openfactor.EvaluationInput) output.ShipConfigConfidence {
+	findings := []output.ShipConfigConfidenceFinding{}
+
+	if baseSpec.Source == output.SHIP_BASE_SPEC_MANUAL {
+		findings = append(findings, output.ShipConfigConfidenceFinding{
+			Check:   output.SHIP_CONFIDENCE_CHECK_SOURCE,
+			Field:   "boat_base_spec",
+			Message: "base spec is specified manually and not backed by an orc certificate",
+			Penalty: CONFIDENCE_MANUAL_SOURCE_PENALTY,
+		})
+	}
+
+	missing := []struct {
+		field     string
+		specified bool
+	}{
+		// the rig type is only expected from manual base specs, orc certificates don't provide it.
+		{"boat_base_spec.rig.type", baseSpec.Source != output.SHIP_BASE_SPEC_MANUAL || baseSpec.Rig.Type != ""},
+		// the composition is considered unspecified if the materials don't cover the majority of the weight.
+		{"boat_extra_spec.composition", factorInput.Composition[openfactor.MATERIAL_DEFAULT] < 50},
+	}
+	for _, field := range missing {
+		if field.specified {
+			continue
+		}
+		findings = append(findings, output.ShipConfigConfidenceFinding{
+			Check:   output.SHIP_CONFIDENCE_CHECK_MISSING,
+			Field:   field.field,
+			Message: "optional field is not specified, the neutral default is used",
+			Penalty: CONFIDENCE_MISSING_PENALTY,
+		})
+	}
+
+	for _, outlier := range CONFIDENCE_OUTLIERS {
+		value := outlier.value(factorInput)
+		if value >= outlier.min && value <= outlier.max {
+			continue
+		}
+		findings = append(findings, output.ShipConfigConfidenceFinding{
+			Check: output.SHIP_CONFIDENCE_CHECK_OUTLIER,
+			Field: outlier.name,
+			Message: fmt.Sprintf("value %.2f is outside of the common range [%g; %g]",
+				value, outlier.min, outlier.max,
+			),
+			Penalty: CONFIDENCE_OUTLIER_PENALTY,
+		})
+	}
+
+	score := 1.0
+	for _, finding := range findings {
+		score -= finding.Penalty
+	}
+	// rounded to avoid float artifacts in the published score.
+	score = math.Max(math.Round(score*100)/100, 0)
+
+	return output.ShipConfigConfidence{
+		Score:    score,
+		Review:   score < CONFIDENCE_REVIEW_THRESHOLD,
+		Findings: findings,
+	}
+}
@@ -20,17 +20,31 @@
 package generate
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"os"
 	"path"
 
 	"github.com/megakuul/opensail/engine/structure/input"
 	"github.com/megakuul/opensail/engine/structure/output"
+	"github.com/megakuul/opensail/openfactor"
 	"github.com/spf13/cobra"
 )
 
 type generateFlags struct {
-	inputPath  string
-	outputPath string
+	inputPath       string
+	outputPath      string
+	calibrationPath string
+	versions        []string
+	shortHanded     []int64
+	autopilot       bool
+
+	uncertainty        bool
+	uncertaintySamples int
+	uncertaintySeed    int64
+
+	orc ORCFlags
 }
 
 func NewGenerateCmd(inputStruct *input.Structure, outputStruct *output.Structure) *cobra.Command {
@@ -41,7 +55,7 @@ func NewGenerateCmd(inputStruct *input.Structure, outputStruct *output.Structure
 		Short:        "generate opensail api data from register",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return Run(flags, inputStruct, outputStruct)
+			return Run(cmd.Context(), cmd.ErrOrStderr(), flags, inputStruct, outputStruct)
 		},
 	}
 
@@ -52,11 +66,77 @@ func NewGenerateCmd(inputStruct *input.Structure, outputStruct *output.Structure
 	cmd.Flags().StringVarP(&flags.outputPath, "output-path", "o",
 		"./out", "specify the data output path",
 	)
+	cmd.Flags().StringVar(&flags.calibrationPath, "calibration",
+		"", "specify a toml calibration file used instead of the built-in openfactor calibration",
+	)
+	cmd.Flags().StringSliceVar(&flags.versions, "versions",
+		[]string{}, "specify additional openfactor versions that are rated for every ship (e.g. v0.0.1,v0.0.2)",
+	)
+	cmd.Flags().Int64SliceVar(&flags.shortHanded, "short-handed",
+		[]int64{}, "specify crew counts that are rated as short-handed variant for every ship (e.g. 1,2)",
+	)
+	cmd.Flags().BoolVar(&flags.autopilot, "short-handed-autopilot",
+		false, "specify whether autopilots are allowed in the short-handed variants",
+	)
+	cmd.Flags().BoolVar(&flags.uncertainty, "uncertainty",
+		false, "publish tcc uncertainty bounds for ships with manual base spec",
+	)
+	cmd.Flags().IntVar(&flags.uncertaintySamples, "uncertainty-samples",
+		1000, "specify the number of samples used to derive the uncertainty bounds",
+	)
+	cmd.Flags().Int64Var(&flags.uncertaintySeed, "uncertainty-seed",
+		1, "specify the seed used to sample the uncertainty bounds (same seed, same bounds)",
+	)
+
+	AddORCFlags(cmd.Flags(), &flags.orc)
+
+	cmd.AddCommand(NewGoldenCmd(inputStruct, outputStruct))
 
 	return cmd
 }
 
-func Run(flags *generateFlags, inputStruct *input.Structure, outputStruct *output.Structure) error {
+func Run(ctx context.Context, w io.Writer, flags *generateFlags, inputStruct *input.Structure, outputStruct *output.Structure) error {
+	calibration, err := LoadCalibration(flags.calibrationPath)
+	if err != nil {
+		return err
+	}
+	orcClient, err := NewORCClient(&flags.orc)
+	if err != nil {
+		return err
+	}
+	// the calibrated algorithm provides the primary rating, other versions are added as rating blocks.
+	algorithms := []openfactor.Algorithm{openfactor.NewAlgorithm(calibration)}
+	for _, version := range flags.versions {
+		if version == calibration.Version {
+			continue
+		}
+		algorithm, err := openfactor.LookupAlgorithm(version)
+		if err != nil {
+			return err
+		}
+		algorithms = append(algorithms, algorithm)
+	}
+	ratingOptions := shipRatingOptions{
+		ageAllowance: calibration.AgeAllowancePerYear > 0,
+	}
+	for _, count := range flags.shortHanded {
+		if count < 1 {
+			return fmt.Errorf("invalid short-handed crew count '%d'", count)
+		}
+		ratingOptions.shortHandedCrews = append(ratingOptions.shortHandedCrews, openfactor.Crew{
+			Count:       count,
+			ShortHanded: true,
+			Autopilot:   flags.autopilot,
+		})
+	}
+	if flags.uncertainty {
+		if flags.uncertaintySamples < 1 {
+			return fmt.Errorf("invalid number of uncertainty samples '%d'", flags.uncertaintySamples)
+		}
+		ratingOptions.uncertaintySamples = flags.uncertaintySamples
+		ratingOptions.uncertaintySeed = flags.uncertaintySeed
+	}
+
 	teamsDirectory, err := os.ReadDir(path.Join(flags.inputPath, inputStruct.Team.BasePath))
 	if err != nil {
 		return err
@@ -102,7 +182,7 @@ func Run(flags *generateFlags, inputStruct *input.Structure, outputStruct *outpu
 		return err
 	}
 
-	shipData, err := generateShips(flags.inputPath, ships, inputStruct.Ship)
+	shipData, err := generateShips(ctx, orcClient, w, flags.inputPath, ships, inputStruct.Ship, algorithms, ratingOptions)
 	if err != nil {
 		return err
 	}
@@ -113,3 +193,20 @@ func Run(flags *generateFlags, inputStruct *input.Structure, outputStruct *outpu
 
 	return nil
 }
+
+// LoadCalibration loads the openfactor calibration from the toml file.
+// If no file is specified, the built-in openfactor calibration is used.
+func LoadCalibration(calibrationPath string) (*openfactor.Calibration, error) {
+	if calibrationPath == "" {
+		return openfactor.DefaultCalibration(), nil
+	}
+	calibrationRaw, err := os.ReadFile(calibrationPath)
+	if err != nil {
+		return nil, err
+	}
+	calibration, err := openfactor.ParseCalibration(calibrationRaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse calibration: %w", err)
+	}
+	return calibration, nil
+}
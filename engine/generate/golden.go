@@ -0,0 +1,196 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package generate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+
+	"github.com/megakuul/opensail/engine/adapter/orc"
+	"github.com/megakuul/opensail/engine/structure/input"
+	"github.com/megakuul/opensail/engine/structure/output"
+	"github.com/megakuul/opensail/openfactor"
+	"github.com/spf13/cobra"
+)
+
+var errMissingFixture = errors.New("missing orc fixture")
+
+type goldenFlags struct {
+	inputPath   string
+	goldenPath  string
+	fixturePath string
+}
+
+func NewGoldenCmd(inputStruct *input.Structure, outputStruct *output.Structure) *cobra.Command {
+	flags := &goldenFlags{}
+
+	cmd := &cobra.Command{
+		Use:          "golden",
+		Short:        "regenerate the golden ratings of the regression suite and record missing orc fixtures",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return RunGolden(cmd.Context(), cmd.OutOrStdout(), flags, inputStruct, outputStruct)
+		},
+	}
+
+	cmd.Flags().SortFlags = false
+	cmd.Flags().StringVarP(&flags.inputPath, "input-path", "i",
+		".", "specify the repository base path",
+	)
+	cmd.Flags().StringVar(&flags.goldenPath, "golden-path",
+		"engine/generate/testdata/golden", "specify the directory of the golden ratings",
+	)
+	cmd.Flags().StringVar(&flags.fixturePath, "fixture-path",
+		"engine/adapter/orc/testdata", "specify the directory of the recorded orc DownBoatRMS responses",
+	)
+
+	return cmd
+}
+
+// RunGolden records the missing orc fixtures from the orc api and regenerates the golden rating of every ship.
+// The ratings are evaluated with the recorded fixtures, exactly as the regression suite evaluates them.
+func RunGolden(ctx context.Context, w io.Writer, flags *goldenFlags, inputStruct *input.Structure, outputStruct *output.Structure) error {
+	orcClient := orc.NewClient(orc.WithHTTPClient(newFixtureHTTPClient(flags.fixturePath, true)))
+
+	shipsDirectory, err := os.ReadDir(path.Join(flags.inputPath, inputStruct.Ship.BasePath))
+	if err != nil {
+		return err
+	}
+	for _, entry := range shipsDirectory {
+		if !entry.IsDir() {
+			continue
+		}
+		ship := entry.Name()
+		ratingRaw, err := generateGoldenRating(ctx, orcClient, flags.inputPath, ship, inputStruct.Ship)
+		if err != nil {
+			return fmt.Errorf("failed to generate golden rating (ship '%s'): %w", ship, err)
+		}
+		if err := os.MkdirAll(flags.goldenPath, 0755); err != nil {
+			return err
+		}
+		goldenPath := path.Join(flags.goldenPath, ship+".json")
+		if err := os.WriteFile(goldenPath, ratingRaw, 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "generated golden rating '%s'\n", goldenPath)
+	}
+	return nil
+}
+
+// fixtureTransport replays the recorded orc DownBoatRMS responses of the fixture directory ('<RefNo>.json').
+// If record is set, missing fixtures are fetched from the orc api and recorded, otherwise they fail with errMissingFixture.
+type fixtureTransport struct {
+	fixturePath string
+	record      bool
+}
+
+// newFixtureHTTPClient returns a http client for the orc client (orc.WithHTTPClient) that replays the recorded fixtures.
+func newFixtureHTTPClient(fixturePath string, record bool) *http.Client {
+	return &http.Client{Transport: &fixtureTransport{fixturePath: fixturePath, record: record}}
+}
+
+func (t *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query()
+	if action := query.Get("action"); action != "DownBoatRMS" {
+		return nil, fmt.Errorf("unsupported orc action '%s'", action)
+	}
+	refNo := query.Get("RefNo")
+	// RefNos are plain identifiers, anything that could escape the fixture directory is rejected.
+	if refNo == "" || path.Base(refNo) != refNo || refNo == ".." {
+		return nil, fmt.Errorf("invalid orc RefNo. '%s'", refNo)
+	}
+
+	refNoFixturePath := path.Join(t.fixturePath, refNo+".json")
+	fixtureRaw, err := os.ReadFile(refNoFixturePath)
+	if errors.Is(err, os.ErrNotExist) {
+		if !t.record {
+			return nil, fmt.Errorf("%w for RefNo. '%s'", errMissingFixture, refNo)
+		}
+		fixtureRaw, err = t.recordFixture(req, refNoFixturePath)
+		if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         req.Proto,
+		ProtoMajor:    req.ProtoMajor,
+		ProtoMinor:    req.ProtoMinor,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(fixtureRaw)),
+		ContentLength: int64(len(fixtureRaw)),
+		Request:       req,
+	}, nil
+}
+
+// recordFixture fetches the response from the orc api and stores it unchanged in the fixture file.
+func (t *fixtureTransport) recordFixture(req *http.Request, fixturePath string) ([]byte, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("recording orc fixture failed: unexpected status '%s'", resp.Status)
+	}
+	fixtureRaw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(t.fixturePath, 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(fixturePath, fixtureRaw, 0644); err != nil {
+		return nil, err
+	}
+	return fixtureRaw, nil
+}
+
+// generateGoldenRating rates the ship with the DefaultCalibration and returns the encoded golden rating.
+func generateGoldenRating(ctx context.Context, orcClient *orc.Client, repoPath, ship string, shipStruct input.ShipStructure) ([]byte, error) {
+	info, baseSpec, extraSpec, err := readShip(ctx, orcClient, repoPath, ship, shipStruct)
+	if err != nil {
+		return nil, err
+	}
+	factorInput, err := generateShipFactorInput(info, baseSpec, extraSpec)
+	if err != nil {
+		return nil, err
+	}
+	rating, err := generateShipRating(factorInput, baseSpec, openfactor.NewAlgorithm(nil), shipRatingOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ratingRaw, err := json.MarshalIndent(rating, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(ratingRaw, '\n'), nil
+}
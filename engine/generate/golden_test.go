@@ -0,0 +1,197 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"testing"
+
+	"github.com/megakuul/opensail/engine/adapter/orc"
+	"github.com/megakuul/opensail/engine/structure/input"
+)
+
+// update regenerates the golden ratings (go test ./generate -update), like 'engine generate golden'.
+// Missing orc fixtures are recorded from the orc api in the same run.
+var update = flag.Bool("update", false, "regenerate the golden ratings and record missing orc fixtures")
+
+const (
+	GOLDEN_REPO_PATH    = "../.."                   // repository base path relative to this package
+	GOLDEN_PATH         = "testdata/golden"         // checked-in ratings per ship
+	GOLDEN_FIXTURE_PATH = "../adapter/orc/testdata" // recorded orc DownBoatRMS responses per RefNo
+	GOLDEN_TOLERANCE    = 1e-9                      // tolerance for float comparison
+)
+
+var goldenShipStruct = input.ShipStructure{
+	BasePath:      "register/ships/",
+	ConfigFile:    "ship.toml",
+	InfoFile:      "info.toml",
+	BaseSpecFile:  "base_spec.toml",
+	ExtraSpecFile: "extra_spec.toml",
+	SailsFile:     "sails.toml",
+	ORCFile:       "orc.json",
+}
+
+// TestGoldenRatings evaluates every ship of the register and compares the rating with the golden rating.
+func TestGoldenRatings(t *testing.T) {
+	// the orc ships are rated with the recorded fixtures instead of the orc api.
+	orcClient := orc.NewClient(orc.WithHTTPClient(newFixtureHTTPClient(GOLDEN_FIXTURE_PATH, *update)))
+
+	shipsDirectory, err := os.ReadDir(path.Join(GOLDEN_REPO_PATH, goldenShipStruct.BasePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entry := range shipsDirectory {
+		if !entry.IsDir() {
+			continue
+		}
+		ship := entry.Name()
+		t.Run(ship, func(t *testing.T) {
+			ratingRaw, err := generateGoldenRating(context.Background(), orcClient, GOLDEN_REPO_PATH, ship, goldenShipStruct)
+			if errors.Is(err, errMissingFixture) {
+				t.Fatalf("%v; record it with 'engine generate golden' while the orc api is reachable", err)
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			goldenPath := path.Join(GOLDEN_PATH, ship+".json")
+			if *update {
+				if err := os.MkdirAll(GOLDEN_PATH, 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(goldenPath, ratingRaw, 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			goldenRaw, err := os.ReadFile(goldenPath)
+			if errors.Is(err, os.ErrNotExist) {
+				t.Fatalf("missing golden rating '%s'; create it with 'go test ./generate -update'", goldenPath)
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			diff, err := diffRatings(goldenRaw, ratingRaw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(diff) > 0 {
+				t.Errorf("rating of ship '%s' differs from the golden rating (regenerate with 'go test ./generate -update' if intended):", ship)
+				for _, line := range diff {
+					t.Log(line)
+				}
+			}
+		})
+	}
+}
+
+// diffRatings compares the ratings value by value and returns a readable line per changed value.
+func diffRatings(goldenRaw, ratingRaw []byte) ([]string, error) {
+	var golden, rating any
+	if err := json.Unmarshal(goldenRaw, &golden); err != nil {
+		return nil, fmt.Errorf("parsing golden rating failed: %w", err)
+	}
+	if err := json.Unmarshal(ratingRaw, &rating); err != nil {
+		return nil, err
+	}
+
+	goldenValues, ratingValues := map[string]any{}, map[string]any{}
+	flattenRating("", golden, goldenValues)
+	flattenRating("", rating, ratingValues)
+
+	keys := map[string]struct{}{}
+	for key := range goldenValues {
+		keys[key] = struct{}{}
+	}
+	for key := range ratingValues {
+		keys[key] = struct{}{}
+	}
+	sortedKeys := []string{}
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	diff := []string{}
+	for _, key := range sortedKeys {
+		goldenValue, goldenOk := goldenValues[key]
+		ratingValue, ratingOk := ratingValues[key]
+		switch {
+		case !goldenOk:
+			diff = append(diff, fmt.Sprintf("  %s: added (%v)", key, ratingValue))
+		case !ratingOk:
+			diff = append(diff, fmt.Sprintf("  %s: removed (was %v)", key, goldenValue))
+		default:
+			goldenNumber, goldenIsNumber := goldenValue.(float64)
+			ratingNumber, ratingIsNumber := ratingValue.(float64)
+			if goldenIsNumber && ratingIsNumber {
+				if math.Abs(goldenNumber-ratingNumber) <= GOLDEN_TOLERANCE {
+					continue
+				}
+				change := ""
+				if goldenNumber != 0 {
+					change = fmt.Sprintf(" (%+.2f%%)", (ratingNumber-goldenNumber)/goldenNumber*100)
+				}
+				diff = append(diff, fmt.Sprintf("  %s: %.6f -> %.6f%s", key, goldenNumber, ratingNumber, change))
+			} else if goldenValue != ratingValue {
+				diff = append(diff, fmt.Sprintf("  %s: %v -> %v", key, goldenValue, ratingValue))
+			}
+		}
+	}
+	return diff, nil
+}
+
+// flattenRating flattens the decoded json into path keyed values (e.g. 'wind_bands[light].tcc').
+// Named list entries (wind bands, courses, trace terms) are keyed by their name instead of their index.
+func flattenRating(prefix string, value any, values map[string]any) {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for key, child := range typedValue {
+			childPrefix := key
+			if prefix != "" {
+				childPrefix = prefix + "." + key
+			}
+			flattenRating(childPrefix, child, values)
+		}
+	case []any:
+		for i, child := range typedValue {
+			key := fmt.Sprintf("%d", i)
+			if entry, ok := child.(map[string]any); ok {
+				if name, ok := entry["name"].(string); ok {
+					key = name
+				} else if term, ok := entry["term"].(string); ok {
+					key = fmt.Sprintf("%v.%s", entry["category"], term)
+				}
+			}
+			flattenRating(fmt.Sprintf("%s[%s]", prefix, key), child, values)
+		}
+	default:
+		values[prefix] = value
+	}
+}
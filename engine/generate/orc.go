@@ -0,0 +1,187 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/megakuul/opensail/engine/adapter/orc"
+	"github.com/megakuul/opensail/engine/structure/input"
+	"github.com/spf13/pflag"
+)
+
+// ORCFlags specifies the flags of commands reading ships from the orc api.
+type ORCFlags struct {
+	Endpoint  string
+	Timeout   time.Duration
+	CachePath string
+	CacheTTL  time.Duration
+	Offline   bool
+}
+
+// AddORCFlags registers the orc api flags on the flag set.
+func AddORCFlags(flagSet *pflag.FlagSet, flags *ORCFlags) {
+	flagSet.StringVar(&flags.Endpoint, "orc-endpoint",
+		orc.ORC_BASE_ENDPOINT, "specify the orc api endpoint (e.g. a local stand-in server)",
+	)
+	flagSet.DurationVar(&flags.Timeout, "orc-timeout",
+		orc.ORC_DEFAULT_TIMEOUT, "specify the timeout of orc api requests (0 disables the timeout)",
+	)
+	flagSet.StringVar(&flags.CachePath, "orc-cache",
+		"", "specify a directory used to cache orc api responses (e.g. ./.orc-cache)",
+	)
+	flagSet.DurationVar(&flags.CacheTTL, "orc-cache-ttl",
+		orc.ORC_DEFAULT_CACHE_TTL, "specify the time cached orc api responses are reused (0 means they never expire)",
+	)
+	flagSet.BoolVar(&flags.Offline, "offline",
+		false, "serve orc data only from the orc cache, fails if a needed RefNo is not cached",
+	)
+}
+
+// NewORCClient creates the orc client specified by the flags.
+func NewORCClient(flags *ORCFlags) (*orc.Client, error) {
+	if flags.Offline && flags.CachePath == "" {
+		return nil, fmt.Errorf("offline mode requires an orc cache (--orc-cache)")
+	}
+	return orc.NewClient(
+		orc.WithBaseURL(flags.Endpoint),
+		orc.WithTimeout(flags.Timeout),
+		orc.WithCache(flags.CachePath, flags.CacheTTL),
+		orc.WithOffline(flags.Offline),
+	), nil
+}
+
+// ShipORCRefNos returns the orc RefNos referenced by the ship configuration.
+func ShipORCRefNos(repoPath, ship string, shipStruct input.ShipStructure) ([]string, error) {
+	shipConfig, err := readShipConfig(path.Join(repoPath, shipStruct.BasePath, ship), shipStruct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ship config (ship '%s'): %w", ship, err)
+	}
+	refNos := []string{}
+	if shipConfig.Info.Source == input.SHIP_INFO_ORC {
+		refNos = append(refNos, shipConfig.Info.ORCRefNo)
+	}
+	if shipConfig.BaseSpec.Source == input.SHIP_BASE_SPEC_ORC && (len(refNos) < 1 || refNos[0] != shipConfig.BaseSpec.ORCRefNo) {
+		refNos = append(refNos, shipConfig.BaseSpec.ORCRefNo)
+	}
+	return refNos, nil
+}
+
+// ReadShipORCSnapshot reads the pinned orc certificates of the ship.
+// Returns nil if the ship has no pinned certificates.
+func ReadShipORCSnapshot(snapshotPath string) (*orc.DownBoatRMS, error) {
+	snapshotRaw, err := os.ReadFile(snapshotPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	snapshot := &orc.DownBoatRMS{}
+	err = json.Unmarshal(snapshotRaw, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("parsing orc snapshot failed: %w", err)
+	}
+	return snapshot, nil
+}
+
+// orcSource resolves the orc certificates of a ship.
+// Pinned certificates are preferred over the live orc api, so that old register states produce the same ratings.
+type orcSource struct {
+	ship   string
+	client *orc.Client
+	// pinned holds the pinned certificates of the ship (nil if the ship has no snapshot).
+	pinned *orc.DownBoatRMS
+	// report receives divergences between pinned and live certificates (nil disables the comparison).
+	report io.Writer
+	// compared holds the RefNos that were already compared with the live certificate.
+	compared map[string]struct{}
+}
+
+func newORCSource(ship string, client *orc.Client, pinned *orc.DownBoatRMS, report io.Writer) *orcSource {
+	return &orcSource{
+		ship:     ship,
+		client:   client,
+		pinned:   pinned,
+		report:   report,
+		compared: map[string]struct{}{},
+	}
+}
+
+// getRMS returns the orc certificate of the RefNo.
+func (s *orcSource) getRMS(ctx context.Context, refNo string) (*orc.RMS, error) {
+	if refNo == "" {
+		return nil, fmt.Errorf("invalid ship orc RefNo. '%s'", refNo)
+	}
+	if s.pinned != nil {
+		for _, pinned := range s.pinned.Rms {
+			if pinned.RefNo == refNo {
+				s.compare(ctx, &pinned)
+				return &pinned, nil
+			}
+		}
+	}
+
+	downBoatRms, err := s.client.GetDownBoatRMS(ctx, refNo)
+	if err != nil {
+		return nil, err
+	}
+	return downBoatRms.Certificate(refNo)
+}
+
+// compare reports if the live certificate of the RefNo diverged from the pinned certificate.
+// The comparison is informational, failures to reach the orc api don't affect the rating.
+func (s *orcSource) compare(ctx context.Context, pinned *orc.RMS) {
+	if s.report == nil {
+		return
+	}
+	if _, ok := s.compared[pinned.RefNo]; ok {
+		return
+	}
+	s.compared[pinned.RefNo] = struct{}{}
+
+	downBoatRms, err := s.client.GetDownBoatRMS(ctx, pinned.RefNo)
+	if errors.Is(err, orc.ErrNotCached) {
+		return
+	} else if err != nil {
+		fmt.Fprintf(s.report, "ship '%s': pinned orc certificate RefNo. '%s' could not be compared with the live certificate: %v\n",
+			s.ship, pinned.RefNo, err,
+		)
+		return
+	}
+	live, err := downBoatRms.Certificate(pinned.RefNo)
+	if err != nil {
+		fmt.Fprintf(s.report, "ship '%s': pinned orc certificate could not be compared with the live certificate: %v\n",
+			s.ship, err,
+		)
+		return
+	}
+	if live.CertNo != pinned.CertNo || live.IssueDate != pinned.IssueDate {
+		fmt.Fprintf(s.report, "ship '%s': pinned orc certificate RefNo. '%s' (CertNo '%s', issued '%s') diverged from the live certificate (CertNo '%s', issued '%s'); re-pin it with 'engine orc pin %s'\n",
+			s.ship, pinned.RefNo, pinned.CertNo, pinned.IssueDate, live.CertNo, live.IssueDate, s.ship,
+		)
+	}
+}
@@ -0,0 +1,96 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+	"github.com/megakuul/opensail/engine/structure/input"
+	"github.com/megakuul/opensail/engine/structure/output"
+	"github.com/megakuul/opensail/openfactor"
+)
+
+// generateShipSailConfigurations rates the ship with every sail configuration declared in the sails file.
+// Sails not specified in a configuration keep the area of the full inventory, ships without sails file have no configurations.
+func generateShipSailConfigurations(sailsPath string, factorInput *openfactor.EvaluationInput, baseSpec *output.ShipConfigBaseSpec, algorithm openfactor.Algorithm, options shipRatingOptions) (map[string]output.ShipConfigSailConfiguration, error) {
+	configurations := map[string]output.ShipConfigSailConfiguration{}
+
+	sailsRaw, err := os.ReadFile(sailsPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return configurations, nil
+	} else if err != nil {
+		return nil, err
+	}
+	sails := &input.ShipSails{}
+	err = toml.Unmarshal(sailsRaw, sails)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, configuration := range sails.Configurations {
+		if _, ok := configurations[configuration.Name]; ok {
+			return nil, fmt.Errorf("duplicate sail configuration '%s'", configuration.Name)
+		}
+
+		configurationInput := ApplySailConfiguration(factorInput, configuration)
+		sailArea := output.ShipConfigBaseSpecSailArea{
+			Main:                configurationInput.MainSailArea,
+			Jib:                 configurationInput.JibSailArea,
+			AsymmetricSpinnaker: configurationInput.AsymmetricSpinnakerArea,
+			SymmetricSpinnaker:  configurationInput.SymmetricSpinnakerArea,
+			CodeZero:            configurationInput.CodeZeroArea,
+		}
+
+		rating, err := generateShipRating(configurationInput, baseSpec, algorithm, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rate sail configuration '%s': %w", configuration.Name, err)
+		}
+		configurations[configuration.Name] = output.ShipConfigSailConfiguration{
+			SailArea: sailArea,
+			Rating:   *rating,
+		}
+	}
+	return configurations, nil
+}
+
+// ApplySailConfiguration returns the evaluation input of the ship raced with the sail configuration.
+// Sails not specified in the configuration keep the area of the full inventory.
+func ApplySailConfiguration(factorInput *openfactor.EvaluationInput, configuration input.ShipSailsConfiguration) *openfactor.EvaluationInput {
+	configurationInput := *factorInput
+	overrides := []struct {
+		value  *float64
+		target *float64
+	}{
+		{configuration.Main, &configurationInput.MainSailArea},
+		{configuration.Jib, &configurationInput.JibSailArea},
+		{configuration.AsymmetricSpinnaker, &configurationInput.AsymmetricSpinnakerArea},
+		{configuration.SymmetricSpinnaker, &configurationInput.SymmetricSpinnakerArea},
+		{configuration.CodeZero, &configurationInput.CodeZeroArea},
+	}
+	for _, override := range overrides {
+		if override.value != nil {
+			*override.target = *override.value
+		}
+	}
+	return &configurationInput
+}
@@ -0,0 +1,67 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package generate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/megakuul/opensail/engine/adapter/orc"
+	"github.com/megakuul/opensail/openfactor"
+)
+
+func TestGenerateShipSailConfigurations(t *testing.T) {
+	orcClient := orc.NewClient(orc.WithHTTPClient(newFixtureHTTPClient(GOLDEN_FIXTURE_PATH, false)))
+	info, baseSpec, extraSpec, err := readShip(context.Background(), orcClient, GOLDEN_REPO_PATH, "sui_example_hobie", goldenShipStruct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	factorInput, err := generateShipFactorInput(info, baseSpec, extraSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	algorithm := openfactor.NewAlgorithm(nil)
+
+	rating, err := generateShipRating(factorInput, baseSpec, algorithm, shipRatingOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configurations, err := generateShipSailConfigurations(
+		"testdata/sails/sui_example_hobie.toml", factorInput, baseSpec, algorithm, shipRatingOptions{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configuration, ok := configurations["no_spinnaker"]
+	if !ok {
+		t.Fatalf("expected sail configuration 'no_spinnaker', got %v", configurations)
+	}
+	if configuration.SailArea.SymmetricSpinnaker != 0 || configuration.SailArea.Main != baseSpec.SailArea.Main {
+		t.Errorf("expected the base spec sail area without spinnaker, got %+v", configuration.SailArea)
+	}
+	// the corrected time is elapsed time / tcc, slower configurations receive a higher tcc.
+	if configuration.Rating.TCC <= rating.TCC {
+		t.Errorf("expected a higher tcc without spinnaker, got %v (full inventory %v)", configuration.Rating.TCC, rating.TCC)
+	}
+	if configuration.Rating.Confidence.Score <= 0 || len(configuration.Rating.Confidence.Findings) < 1 {
+		t.Errorf("expected a confidence score of the manual base spec, got %+v", configuration.Rating.Confidence)
+	}
+}
@@ -20,8 +20,10 @@
 package generate
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"strconv"
@@ -33,28 +35,42 @@ import (
 	"github.com/megakuul/opensail/openfactor"
 )
 
+// shipRatingOptions specifies the optional parts of the ship ratings.
+type shipRatingOptions struct {
+	// shortHandedCrews specifies the crews that are rated as short-handed variant.
+	shortHandedCrews []openfactor.Crew
+	// uncertaintySamples specifies the number of samples of the uncertainty bounds (0 disables the bounds).
+	uncertaintySamples int
+	// uncertaintySeed specifies the seed used to sample the uncertainty bounds.
+	uncertaintySeed int64
+	// ageAllowance specifies that the calibration grants an age allowance (ships require a known construction year).
+	ageAllowance bool
+}
+
 // generateShips generates the shipMap.
-func generateShips(repoPath string, ships map[string]struct{}, shipStruct input.ShipStructure) ([]byte, error) {
+// The first algorithm provides the primary rating, every algorithm provides a rating block.
+func generateShips(ctx context.Context, orcClient *orc.Client, report io.Writer, repoPath string, ships map[string]struct{}, shipStruct input.ShipStructure, algorithms []openfactor.Algorithm, options shipRatingOptions) ([]byte, error) {
 	shipMap := output.ShipMap{}
 
 	for ship := range ships {
 		shipPath := path.Join(repoPath, shipStruct.BasePath, ship)
-		shipConfigRaw, err := os.ReadFile(path.Join(shipPath, shipStruct.ConfigFile))
+		shipConfig, err := readShipConfig(shipPath, shipStruct)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read ship config (ship '%s'): %w", ship, err)
 		}
-		shipConfig := &input.ShipConfig{}
-		err = toml.Unmarshal(shipConfigRaw, shipConfig)
+
+		pinned, err := ReadShipORCSnapshot(path.Join(shipPath, shipStruct.ORCFile))
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse ship config (ship '%s'): %w", ship, err)
+			return nil, fmt.Errorf("failed to read ship orc snapshot (ship '%s'): %w", ship, err)
 		}
+		orcSource := newORCSource(ship, orcClient, pinned, report)
 
-		outputShipInfo, err := generateShipInfo(shipConfig.Info, path.Join(shipPath, shipStruct.InfoFile))
+		outputShipInfo, err := generateShipInfo(ctx, orcSource, shipConfig.Info, path.Join(shipPath, shipStruct.InfoFile))
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate ship info (ship '%s'): %w", ship, err)
 		}
 
-		outputShipBaseSpec, err := generateShipBaseSpec(shipConfig.BaseSpec, path.Join(shipPath, shipStruct.BaseSpecFile))
+		outputShipBaseSpec, err := generateShipBaseSpec(ctx, orcSource, shipConfig.BaseSpec, path.Join(shipPath, shipStruct.BaseSpecFile))
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate ship spec (ship '%s'): %w", ship, err)
 		}
@@ -64,17 +80,43 @@ func generateShips(repoPath string, ships map[string]struct{}, shipStruct input.
 			return nil, fmt.Errorf("failed to generate ship spec (ship '%s'): %w", ship, err)
 		}
 
-		outputShipRating, err := generateShipRating(outputShipBaseSpec, outputShipExtraSpec)
+		factorInput, err := generateShipFactorInput(outputShipInfo, outputShipBaseSpec, outputShipExtraSpec)
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate ship rating (ship '%s'): %w", ship, err)
 		}
+		if options.ageAllowance && factorInput.ConstructionYear == 0 {
+			return nil, fmt.Errorf("unknown ship construction year '%s'; required by the age allowance (ship '%s')", outputShipInfo.Age, ship)
+		}
+
+		outputShipRatings := map[string]output.ShipConfigRating{}
+		for _, algorithm := range algorithms {
+			outputShipRating, err := generateShipRating(factorInput, outputShipBaseSpec, algorithm, options)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate ship rating (ship '%s', openfactor '%s'): %w", ship, algorithm.Version(), err)
+			}
+			outputShipRatings[outputShipRating.Version] = *outputShipRating
+		}
+
+		outputShipSailConfigurations, err := generateShipSailConfigurations(
+			path.Join(shipPath, shipStruct.SailsFile),
+			factorInput,
+			outputShipBaseSpec,
+			algorithms[0],
+			options,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate ship sail configurations (ship '%s'): %w", ship, err)
+		}
 
 		shipMap[ship] = output.ShipConfig{
 			Team:          shipConfig.Team,
 			ShipInfo:      *outputShipInfo,
 			ShipBaseSpec:  *outputShipBaseSpec,
 			ShipExtraSpec: *outputShipExtraSpec,
-			ShipRating:    *outputShipRating,
+			ShipRating:    outputShipRatings[algorithms[0].Version()],
+			ShipRatings:   outputShipRatings,
+
+			ShipSailConfigurations: outputShipSailConfigurations,
 		}
 	}
 
@@ -86,7 +128,63 @@ func generateShips(repoPath string, ships map[string]struct{}, shipStruct input.
 	return shipMapRaw, nil
 }
 
-func generateShipInfo(info input.ShipConfigInfo, infoPath string) (*output.ShipConfigInfo, error) {
+// GenerateShipFactorInput reads the ship from the register and derives the openfactor evaluation input.
+func GenerateShipFactorInput(ctx context.Context, orcClient *orc.Client, repoPath, ship string, shipStruct input.ShipStructure) (*openfactor.EvaluationInput, error) {
+	outputShipInfo, outputShipBaseSpec, outputShipExtraSpec, err := readShip(ctx, orcClient, repoPath, ship, shipStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return generateShipFactorInput(outputShipInfo, outputShipBaseSpec, outputShipExtraSpec)
+}
+
+// readShip reads the ship from the register and generates its info, base and extra spec.
+func readShip(ctx context.Context, orcClient *orc.Client, repoPath, ship string, shipStruct input.ShipStructure) (*output.ShipConfigInfo, *output.ShipConfigBaseSpec, *output.ShipConfigExtraSpec, error) {
+	shipPath := path.Join(repoPath, shipStruct.BasePath, ship)
+	shipConfig, err := readShipConfig(shipPath, shipStruct)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read ship config (ship '%s'): %w", ship, err)
+	}
+
+	pinned, err := ReadShipORCSnapshot(path.Join(shipPath, shipStruct.ORCFile))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read ship orc snapshot (ship '%s'): %w", ship, err)
+	}
+	orcSource := newORCSource(ship, orcClient, pinned, nil)
+
+	outputShipInfo, err := generateShipInfo(ctx, orcSource, shipConfig.Info, path.Join(shipPath, shipStruct.InfoFile))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to generate ship info (ship '%s'): %w", ship, err)
+	}
+
+	outputShipBaseSpec, err := generateShipBaseSpec(ctx, orcSource, shipConfig.BaseSpec, path.Join(shipPath, shipStruct.BaseSpecFile))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to generate ship spec (ship '%s'): %w", ship, err)
+	}
+
+	outputShipExtraSpec, err := generateShipExtraSpec(shipConfig.ExtraSpec, path.Join(shipPath, shipStruct.ExtraSpecFile))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to generate ship spec (ship '%s'): %w", ship, err)
+	}
+
+	return outputShipInfo, outputShipBaseSpec, outputShipExtraSpec, nil
+}
+
+// readShipConfig reads and parses the ship configuration.
+func readShipConfig(shipPath string, shipStruct input.ShipStructure) (*input.ShipConfig, error) {
+	shipConfigRaw, err := os.ReadFile(path.Join(shipPath, shipStruct.ConfigFile))
+	if err != nil {
+		return nil, err
+	}
+	shipConfig := &input.ShipConfig{}
+	err = toml.Unmarshal(shipConfigRaw, shipConfig)
+	if err != nil {
+		return nil, err
+	}
+	return shipConfig, nil
+}
+
+func generateShipInfo(ctx context.Context, orcSource *orcSource, info input.ShipConfigInfo, infoPath string) (*output.ShipConfigInfo, error) {
 	switch info.Source {
 	case input.SHIP_INFO_MANUAL:
 		shipInfoRaw, err := os.ReadFile(infoPath)
@@ -108,20 +206,11 @@ func generateShipInfo(info input.ShipConfigInfo, infoPath string) (*output.ShipC
 			Designer: shipInfo.Designer,
 		}, nil
 	case input.SHIP_INFO_ORC:
-		if info.ORCRefNo == "" {
-			return nil, fmt.Errorf("invalid ship orc RefNo. '%s'", info.ORCRefNo)
-		}
-
-		downBoatRms, err := orc.GetDownBoatRMS(info.ORCRefNo)
+		orcShip, err := orcSource.getRMS(ctx, info.ORCRefNo)
 		if err != nil {
 			return nil, err
 		}
 
-		if len(downBoatRms.Rms) < 1 {
-			return nil, fmt.Errorf("ship with RefNo. '%s' was not found on orc database", info.ORCRefNo)
-		}
-		orcShip := downBoatRms.Rms[0]
-
 		return &output.ShipConfigInfo{
 			Source:   output.SHIP_INFO_ORC,
 			Name:     orcShip.YachtName,
@@ -135,7 +224,7 @@ func generateShipInfo(info input.ShipConfigInfo, infoPath string) (*output.ShipC
 	}
 }
 
-func generateShipBaseSpec(spec input.ShipConfigBaseSpec, specPath string) (*output.ShipConfigBaseSpec, error) {
+func generateShipBaseSpec(ctx context.Context, orcSource *orcSource, spec input.ShipConfigBaseSpec, specPath string) (*output.ShipConfigBaseSpec, error) {
 	switch spec.Source {
 	case input.SHIP_BASE_SPEC_MANUAL:
 		shipSpecRaw, err := os.ReadFile(specPath)
@@ -147,6 +236,10 @@ func generateShipBaseSpec(spec input.ShipConfigBaseSpec, specPath string) (*outp
 		if err != nil {
 			return nil, err
 		}
+		tolerance := shipSpec.Tolerance
+		if tolerance == nil {
+			tolerance = map[string]float64{}
+		}
 
 		return &output.ShipConfigBaseSpec{
 			Source: output.SHIP_BASE_SPEC_MANUAL,
@@ -164,23 +257,22 @@ func generateShipBaseSpec(spec input.ShipConfigBaseSpec, specPath string) (*outp
 				Jib:                 shipSpec.SailArea.Jib,
 				AsymmetricSpinnaker: shipSpec.SailArea.AsymmetricSpinnaker,
 				SymmetricSpinnaker:  shipSpec.SailArea.SymmetricSpinnaker,
+				CodeZero:            shipSpec.SailArea.CodeZero,
+			},
+			Rig: output.ShipConfigBaseSpecRig{
+				Type:           output.SHIP_BASE_SPEC_RIG_TYPE(shipSpec.Rig.Type),
+				BowspritLength: shipSpec.Rig.BowspritLength,
+				Spreaders:      shipSpec.Rig.Spreaders,
+				FurlingMain:    shipSpec.Rig.FurlingMain,
 			},
+			Tolerance: tolerance,
 		}, nil
 	case input.SHIP_BASE_SPEC_ORC:
-		if spec.ORCRefNo == "" {
-			return nil, fmt.Errorf("invalid ship orc RefNo. '%s'", spec.ORCRefNo)
-		}
-
-		downBoatRms, err := orc.GetDownBoatRMS(spec.ORCRefNo)
+		orcShip, err := orcSource.getRMS(ctx, spec.ORCRefNo)
 		if err != nil {
 			return nil, err
 		}
 
-		if len(downBoatRms.Rms) < 1 {
-			return nil, fmt.Errorf("ship with RefNo. '%s' was not found on orc database", spec.ORCRefNo)
-		}
-		orcShip := downBoatRms.Rms[0]
-
 		return &output.ShipConfigBaseSpec{
 			Source: output.SHIP_BASE_SPEC_ORC,
 			Dimension: output.ShipConfigBaseSpecDimension{
@@ -197,7 +289,16 @@ func generateShipBaseSpec(spec input.ShipConfigBaseSpec, specPath string) (*outp
 				Jib:                 orcShip.AreaJib,
 				AsymmetricSpinnaker: orcShip.AreaAsym,
 				SymmetricSpinnaker:  orcShip.AreaSym,
+				CodeZero:            spec.CodeZero,
 			},
+			// the orc certificate doesn't provide the rig, it is specified next to the orc source.
+			Rig: output.ShipConfigBaseSpecRig{
+				Type:           output.SHIP_BASE_SPEC_RIG_TYPE(spec.Rig.Type),
+				BowspritLength: spec.Rig.BowspritLength,
+				Spreaders:      spec.Rig.Spreaders,
+				FurlingMain:    spec.Rig.FurlingMain,
+			},
+			Tolerance: map[string]float64{},
 		}, nil
 	default:
 		return nil, fmt.Errorf("invalid ship base spec source '%s'", spec.Source)
@@ -239,7 +340,120 @@ func generateShipExtraSpec(spec input.ShipConfigExtraSpec, specPath string) (*ou
 	}
 }
 
-func generateShipRating(baseSpec *output.ShipConfigBaseSpec, extraSpec *output.ShipConfigExtraSpec) (*output.ShipConfigRating, error) {
+// generateShipRating evaluates the ship with the algorithm.
+// The rating carries the confidence score derived from the base spec and the evaluated input.
+// Uncertainty bounds are only added if enabled in the options and if the base spec has tolerances.
+func generateShipRating(factorInput *openfactor.EvaluationInput, baseSpec *output.ShipConfigBaseSpec, algorithm openfactor.Algorithm, options shipRatingOptions) (*output.ShipConfigRating, error) {
+	factorOutput, err := algorithm.Evaluate(factorInput)
+	if err != nil {
+		return nil, err
+	}
+
+	var uncertainty *output.ShipConfigRatingUncertainty
+	if tolerances := generateShipTolerances(baseSpec); options.uncertaintySamples > 0 && len(tolerances) > 0 {
+		factorUncertainty, err := openfactor.EvaluateUncertainty(
+			algorithm, factorInput, tolerances, options.uncertaintySamples, options.uncertaintySeed,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("uncertainty: %w", err)
+		}
+		uncertainty = &output.ShipConfigRatingUncertainty{
+			Samples:         factorUncertainty.Samples,
+			Rejected:        factorUncertainty.Rejected,
+			Median:          factorUncertainty.Median,
+			Lower:           factorUncertainty.Lower,
+			Upper:           factorUncertainty.Upper,
+			LowerPercentile: openfactor.UNCERTAINTY_LOWER_PERCENTILE,
+			UpperPercentile: openfactor.UNCERTAINTY_UPPER_PERCENTILE,
+		}
+	}
+
+	shortHanded := []output.ShipConfigRatingShortHanded{}
+	for _, crew := range options.shortHandedCrews {
+		shortHandedInput := *factorInput
+		shortHandedInput.Crew = crew
+		shortHandedOutput, err := algorithm.Evaluate(&shortHandedInput)
+		if err != nil {
+			return nil, fmt.Errorf("short-handed variant (crew '%d'): %w", crew.Count, err)
+		}
+		shortHanded = append(shortHanded, output.ShipConfigRatingShortHanded{
+			CrewCount:     crew.Count,
+			Autopilot:     crew.Autopilot,
+			TCC:           shortHandedOutput.TCC,
+			SpeedFactor:   shortHandedOutput.SpeedFactor,
+			AgilityFactor: shortHandedOutput.AgilityFactor,
+		})
+	}
+
+	windBands := []output.ShipConfigRatingWindBand{}
+	for _, band := range factorOutput.WindBands {
+		windBands = append(windBands, output.ShipConfigRatingWindBand{
+			Name:         band.Name,
+			MinWindSpeed: band.MinWindSpeed,
+			MaxWindSpeed: band.MaxWindSpeed,
+			TCC:          band.TCC,
+		})
+	}
+
+	courses := []output.ShipConfigRatingCourse{}
+	for _, course := range factorOutput.Courses {
+		courses = append(courses, output.ShipConfigRatingCourse{
+			Name:     course.Name,
+			Upwind:   course.Upwind,
+			Reach:    course.Reach,
+			Downwind: course.Downwind,
+			TCC:      course.TCC,
+		})
+	}
+
+	trace := []output.ShipConfigRatingTrace{}
+	for _, entry := range factorOutput.Trace {
+		trace = append(trace, output.ShipConfigRatingTrace{
+			Category: string(entry.Category),
+			Term:     entry.Term,
+			Value:    entry.Value,
+		})
+	}
+
+	return &output.ShipConfigRating{
+		Version:             factorOutput.Version,
+		TCC:                 factorOutput.TCC,
+		SpeedFactor:         factorOutput.SpeedFactor,
+		SpeedInfluence:      factorOutput.SpeedInfluence,
+		SpeedDragPoints:     factorOutput.SpeedDragPoints,
+		SpeedUpwindPoints:   factorOutput.SpeedUpwindPoints,
+		SpeedDownwindPoints: factorOutput.SpeedDownwindPoints,
+		SpeedReachPoints:    factorOutput.SpeedReachPoints,
+
+		StabilizationFactor:    factorOutput.StabilizationFactor,
+		StabilizationInfluence: factorOutput.StabilizationInfluence,
+		StabilizationPoints:    factorOutput.StabilizationPoints,
+
+		AgilityFactor:    factorOutput.AgilityFactor,
+		AgilityInfluence: factorOutput.AgilityInfluence,
+		AgilityPoints:    factorOutput.AgilityPoints,
+
+		AgeAllowance: factorOutput.AgeAllowance,
+
+		SecondsPerMile: factorOutput.SecondsPerMile,
+		PLT:            factorOutput.PLT,
+		PLD:            factorOutput.PLD,
+
+		WindBands: windBands,
+		Courses:   courses,
+
+		ShortHanded: shortHanded,
+
+		Confidence: generateShipConfidence(baseSpec, factorInput),
+
+		Uncertainty: uncertainty,
+
+		Trace: trace,
+	}, nil
+}
+
+// generateShipFactorInput derives the openfactor evaluation input from the ship info and specifications.
+func generateShipFactorInput(info *output.ShipConfigInfo, baseSpec *output.ShipConfigBaseSpec, extraSpec *output.ShipConfigExtraSpec) (*openfactor.EvaluationInput, error) {
 	mode := openfactor.MODE_DEFAULT
 	switch extraSpec.Design.Mode {
 	case output.SHIP_EXTRA_SPEC_DESIGN_HYDROFOIL:
@@ -266,6 +480,12 @@ func generateShipRating(baseSpec *output.ShipConfigBaseSpec, extraSpec *output.S
 		stabilization = openfactor.STABILIZATION_DAGGERBOARD
 	case output.SHIP_EXTRA_SPEC_DESIGN_FOILS:
 		stabilization = openfactor.STABILIZATION_FOILS
+	case output.SHIP_EXTRA_SPEC_DESIGN_LIFTINGKEEL:
+		stabilization = openfactor.STABILIZATION_LIFTINGKEEL
+	case output.SHIP_EXTRA_SPEC_DESIGN_SWINGKEEL:
+		stabilization = openfactor.STABILIZATION_SWINGKEEL
+	case output.SHIP_EXTRA_SPEC_DESIGN_CANTINGKEEL:
+		stabilization = openfactor.STABILIZATION_CANTINGKEEL
 	}
 
 	hull := openfactor.HULL_DEFAULT
@@ -274,6 +494,20 @@ func generateShipRating(baseSpec *output.ShipConfigBaseSpec, extraSpec *output.S
 		hull = openfactor.HULL_MONO
 	case output.SHIP_EXTRA_SPEC_DESIGN_MULTI:
 		hull = openfactor.HULL_MULTI
+	case output.SHIP_EXTRA_SPEC_DESIGN_CATAMARAN:
+		hull = openfactor.HULL_CATAMARAN
+	case output.SHIP_EXTRA_SPEC_DESIGN_TRIMARAN:
+		hull = openfactor.HULL_TRIMARAN
+	case output.SHIP_EXTRA_SPEC_DESIGN_PROA:
+		hull = openfactor.HULL_PROA
+	}
+
+	rig := openfactor.RIG_DEFAULT
+	switch baseSpec.Rig.Type {
+	case output.SHIP_BASE_SPEC_RIG_FRACTIONAL:
+		rig = openfactor.RIG_FRACTIONAL
+	case output.SHIP_BASE_SPEC_RIG_MASTHEAD:
+		rig = openfactor.RIG_MASTHEAD
 	}
 
 	var defaultComposition float64 = 100.0
@@ -288,7 +522,13 @@ func generateShipRating(baseSpec *output.ShipConfigBaseSpec, extraSpec *output.S
 		return nil, fmt.Errorf("invalid ship composition; exceeded 100%%")
 	}
 
-	factorOutput, err := openfactor.EvaluateFactor(&openfactor.EvaluationInput{
+	// the age is the construction year, ships with unknown age (empty, unparsable or orc '0') receive no age allowance.
+	var constructionYear int64
+	if year, err := strconv.ParseInt(info.Age, 10, 64); err == nil && year > 0 {
+		constructionYear = year
+	}
+
+	return &openfactor.EvaluationInput{
 		LOA:                     baseSpec.Dimension.LengthOverAll,
 		MaxDraft:                baseSpec.Dimension.Draft,
 		MaxBeam:                 baseSpec.Dimension.Beam,
@@ -298,11 +538,19 @@ func generateShipRating(baseSpec *output.ShipConfigBaseSpec, extraSpec *output.S
 		JibSailArea:             baseSpec.SailArea.Jib,
 		AsymmetricSpinnakerArea: baseSpec.SailArea.AsymmetricSpinnaker,
 		SymmetricSpinnakerArea:  baseSpec.SailArea.SymmetricSpinnaker,
+		CodeZeroArea:            baseSpec.SailArea.CodeZero,
 		Displacement:            baseSpec.Dimension.Displacement,
 		CrewWeight:              baseSpec.Dimension.CrewWeight,
 		Mode:                    mode,
 		Stabilization:           stabilization,
 		Hull:                    hull,
+		ConstructionYear:        constructionYear,
+		Rig: openfactor.Rig{
+			Type:           rig,
+			BowspritLength: baseSpec.Rig.BowspritLength,
+			Spreaders:      baseSpec.Rig.Spreaders,
+			FurlingMain:    baseSpec.Rig.FurlingMain,
+		},
 		Composition: map[openfactor.MATERIAL]float64{
 			openfactor.MATERIAL_DEFAULT: defaultComposition,
 			openfactor.MATERIAL_BALLAST: extraSpec.Composition.BallastPercentage,
@@ -313,26 +561,5 @@ func generateShipRating(baseSpec *output.ShipConfigBaseSpec, extraSpec *output.S
 			openfactor.MATERIAL_ENGINE:  extraSpec.Composition.EnginePercentage,
 			openfactor.MATERIAL_AMENITY: extraSpec.Composition.AmenityPercentage,
 		},
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return &output.ShipConfigRating{
-		Version:             openfactor.Version(),
-		TCC:                 factorOutput.TCC,
-		SpeedFactor:         factorOutput.SpeedFactor,
-		SpeedInfluence:      factorOutput.SpeedInfluence,
-		SpeedDragPoints:     factorOutput.SpeedDragPoints,
-		SpeedUpwindPoints:   factorOutput.SpeedUpwindPoints,
-		SpeedDownwindPoints: factorOutput.SpeedDownwindPoints,
-
-		StabilizationFactor:    factorOutput.StabilizationFactor,
-		StabilizationInfluence: factorOutput.StabilizationInfluence,
-		StabilizationPoints:    factorOutput.StabilizationPoints,
-
-		AgilityFactor:    factorOutput.AgilityFactor,
-		AgilityInfluence: factorOutput.AgilityInfluence,
-		AgilityPoints:    factorOutput.AgilityPoints,
 	}, nil
 }
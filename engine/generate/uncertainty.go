@@ -0,0 +1,53 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package generate
+
+import (
+	"maps"
+
+	"github.com/megakuul/opensail/engine/structure/output"
+)
+
+// MANUAL_TOLERANCES specifies the default relative tolerance of manually measured inputs.
+// Ships can override the tolerances in the '[tolerance]' table of the base spec.
+var MANUAL_TOLERANCES = map[string]float64{
+	"loa":                       0.01, // length is easy to measure
+	"max_draft":                 0.03,
+	"max_beam":                  0.02,
+	"imsl":                      0.03,
+	"wsa":                       0.15, // wetted surface is hard to measure without hull lines
+	"main_sail_area":            0.05,
+	"jib_sail_area":             0.05,
+	"asymmetric_spinnaker_area": 0.05,
+	"symmetric_spinnaker_area":  0.05,
+	"displacement":              0.1, // owners rarely weigh the ship in sailing condition
+	"crew_weight":               0.1,
+}
+
+// generateShipTolerances derives the tolerances used for the uncertainty of the ship rating.
+// Ships with orc base spec are measured and have no tolerances.
+func generateShipTolerances(baseSpec *output.ShipConfigBaseSpec) map[string]float64 {
+	if baseSpec.Source != output.SHIP_BASE_SPEC_MANUAL {
+		return nil
+	}
+	tolerances := maps.Clone(MANUAL_TOLERANCES)
+	maps.Copy(tolerances, baseSpec.Tolerance)
+	return tolerances
+}
@@ -10,7 +10,6 @@ require (
 	github.com/go-playground/universal-translator v0.18.1 // indirect
 	github.com/google/go-querystring v1.1.0 // indirect
 	github.com/leodido/go-urn v1.4.0 // indirect
-	github.com/megakuul/opensail/openfactor v0.0.1 // indirect
 	golang.org/x/crypto v0.19.0 // indirect
 	golang.org/x/net v0.21.0 // indirect
 	golang.org/x/sys v0.17.0 // indirect
@@ -22,5 +21,6 @@ require (
 	github.com/go-playground/validator/v10 v10.23.0
 	github.com/google/go-github/v67 v67.0.0
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/megakuul/opensail/openfactor v0.0.2
 	github.com/spf13/pflag v1.0.5 // indirect
 )
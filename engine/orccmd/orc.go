@@ -0,0 +1,40 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package orccmd provides the commands operating on the orc certificates of the register.
+package orccmd
+
+import (
+	"github.com/megakuul/opensail/engine/structure/input"
+	"github.com/megakuul/opensail/engine/structure/output"
+	"github.com/spf13/cobra"
+)
+
+func NewORCCmd(inputStruct *input.Structure, outputStruct *output.Structure) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "orc",
+		Short:        "manage the orc certificates of registered ships",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(NewPinCmd(inputStruct, outputStruct))
+	cmd.AddCommand(NewSearchCmd(inputStruct, outputStruct))
+
+	return cmd
+}
@@ -0,0 +1,113 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package orccmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/megakuul/opensail/engine/adapter/orc"
+	"github.com/megakuul/opensail/engine/generate"
+	"github.com/megakuul/opensail/engine/structure/input"
+	"github.com/megakuul/opensail/engine/structure/output"
+	"github.com/spf13/cobra"
+)
+
+type pinFlags struct {
+	inputPath string
+
+	orc generate.ORCFlags
+}
+
+func NewPinCmd(inputStruct *input.Structure, outputStruct *output.Structure) *cobra.Command {
+	flags := &pinFlags{}
+
+	cmd := &cobra.Command{
+		Use:          "pin <ship>",
+		Short:        "pin the live orc certificates of the ship into the register",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunPin(cmd.Context(), cmd.OutOrStdout(), args[0], flags, inputStruct, outputStruct)
+		},
+	}
+
+	cmd.Flags().SortFlags = false
+	cmd.Flags().StringVarP(&flags.inputPath, "input-path", "i",
+		".", "specify the repository base path",
+	)
+	generate.AddORCFlags(cmd.Flags(), &flags.orc)
+
+	return cmd
+}
+
+// RunPin fetches the live orc certificates referenced by the ship and stores them as snapshot in the register.
+// Generation prefers the snapshot over the live certificates, so that ratings are reproducible.
+func RunPin(ctx context.Context, w io.Writer, ship string, flags *pinFlags, inputStruct *input.Structure, outputStruct *output.Structure) error {
+	orcClient, err := generate.NewORCClient(&flags.orc)
+	if err != nil {
+		return err
+	}
+
+	refNos, err := generate.ShipORCRefNos(flags.inputPath, ship, inputStruct.Ship)
+	if err != nil {
+		return err
+	}
+	if len(refNos) < 1 {
+		return fmt.Errorf("ship '%s' has no orc sourced info or base spec", ship)
+	}
+
+	snapshot := &orc.DownBoatRMS{Rms: []orc.RMS{}}
+	for _, refNo := range refNos {
+		if refNo == "" {
+			return fmt.Errorf("invalid ship orc RefNo. '%s'", refNo)
+		}
+		downBoatRms, err := orcClient.GetDownBoatRMS(ctx, refNo)
+		if err != nil {
+			return err
+		}
+		certificate, err := downBoatRms.Certificate(refNo)
+		if err != nil {
+			return err
+		}
+		snapshot.Rms = append(snapshot.Rms, *certificate)
+	}
+
+	snapshotRaw, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	snapshotPath := path.Join(flags.inputPath, inputStruct.Ship.BasePath, ship, inputStruct.Ship.ORCFile)
+	err = os.WriteFile(snapshotPath, append(snapshotRaw, '\n'), 0644)
+	if err != nil {
+		return err
+	}
+
+	for _, rms := range snapshot.Rms {
+		fmt.Fprintf(w, "pinned orc certificate RefNo. '%s' (CertNo '%s', issued '%s') to '%s'\n",
+			rms.RefNo, rms.CertNo, rms.IssueDate, snapshotPath,
+		)
+	}
+	return nil
+}
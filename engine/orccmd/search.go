@@ -0,0 +1,111 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package orccmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/megakuul/opensail/engine/adapter/orc"
+	"github.com/megakuul/opensail/engine/generate"
+	"github.com/megakuul/opensail/engine/structure/input"
+	"github.com/megakuul/opensail/engine/structure/output"
+	"github.com/spf13/cobra"
+)
+
+type searchFlags struct {
+	sailNo    string
+	yachtName string
+	natAuth   string
+
+	orc generate.ORCFlags
+}
+
+func NewSearchCmd(inputStruct *input.Structure, outputStruct *output.Structure) *cobra.Command {
+	flags := &searchFlags{}
+
+	cmd := &cobra.Command{
+		Use:          "search",
+		Short:        "list orc certificates by sail number, yacht name or national authority",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return RunSearch(cmd.Context(), cmd.OutOrStdout(), flags, inputStruct, outputStruct)
+		},
+	}
+
+	cmd.Flags().SortFlags = false
+	cmd.Flags().StringVar(&flags.sailNo, "sail-no",
+		"", "specify the sail number (e.g. 'SUI 123')",
+	)
+	cmd.Flags().StringVar(&flags.yachtName, "yacht-name",
+		"", "specify (a part of) the yacht name",
+	)
+	cmd.Flags().StringVar(&flags.natAuth, "nat-auth",
+		"", "specify the national authority issuing the certificate (e.g. 'SUI')",
+	)
+	cmd.MarkFlagsOneRequired("sail-no", "yacht-name", "nat-auth")
+	generate.AddORCFlags(cmd.Flags(), &flags.orc)
+
+	return cmd
+}
+
+// RunSearch lists the orc certificates matching the query, so that registrants can find the orc_ref_no of their ship.
+func RunSearch(ctx context.Context, w io.Writer, flags *searchFlags, inputStruct *input.Structure, outputStruct *output.Structure) error {
+	orcClient, err := generate.NewORCClient(&flags.orc)
+	if err != nil {
+		return err
+	}
+
+	downBoatRms, err := orcClient.SearchRMS(ctx, orc.SearchQuery{
+		SailNo:    flags.sailNo,
+		YachtName: flags.yachtName,
+		NatAuth:   flags.natAuth,
+	})
+	if err != nil {
+		return err
+	}
+	if len(downBoatRms.Rms) < 1 {
+		fmt.Fprintln(w, "no orc certificates found")
+		return nil
+	}
+	// newest certificates first, they are usually the ones to register.
+	sort.SliceStable(downBoatRms.Rms, func(i, j int) bool {
+		return downBoatRms.Rms[i].IssueDate > downBoatRms.Rms[j].IssueDate
+	})
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "RefNo\tCertNo\tNatAuth\tSailNo\tYachtName\tClass\tFamily\tIssueDate\t")
+	for _, rms := range downBoatRms.Rms {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
+			rms.RefNo,
+			rms.CertNo,
+			rms.NatAuth,
+			rms.SailNo,
+			rms.YachtName,
+			rms.Class,
+			rms.Family,
+			rms.IssueDate,
+		)
+	}
+	return table.Flush()
+}
@@ -0,0 +1,130 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package sensitivity
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/megakuul/opensail/engine/generate"
+	"github.com/megakuul/opensail/engine/structure/input"
+	"github.com/megakuul/opensail/engine/structure/output"
+	"github.com/megakuul/opensail/openfactor"
+	"github.com/spf13/cobra"
+)
+
+type sensitivityFlags struct {
+	inputPath       string
+	calibrationPath string
+
+	orc generate.ORCFlags
+}
+
+func NewSensitivityCmd(inputStruct *input.Structure, outputStruct *output.Structure) *cobra.Command {
+	flags := &sensitivityFlags{}
+
+	cmd := &cobra.Command{
+		Use:          "sensitivity <ship>",
+		Short:        "print the sensitivity of the ship rating to each numeric input",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Run(cmd.Context(), cmd.OutOrStdout(), args[0], flags, inputStruct, outputStruct)
+		},
+	}
+
+	cmd.Flags().SortFlags = false
+	cmd.Flags().StringVarP(&flags.inputPath, "input-path", "i",
+		".", "specify the repository base path",
+	)
+	cmd.Flags().StringVar(&flags.calibrationPath, "calibration",
+		"", "specify a toml calibration file used instead of the built-in openfactor calibration",
+	)
+
+	generate.AddORCFlags(cmd.Flags(), &flags.orc)
+
+	return cmd
+}
+
+func Run(ctx context.Context, w io.Writer, ship string, flags *sensitivityFlags, inputStruct *input.Structure, outputStruct *output.Structure) error {
+	calibration, err := generate.LoadCalibration(flags.calibrationPath)
+	if err != nil {
+		return err
+	}
+	orcClient, err := generate.NewORCClient(&flags.orc)
+	if err != nil {
+		return err
+	}
+
+	factorInput, err := generate.GenerateShipFactorInput(ctx, orcClient, flags.inputPath, ship, inputStruct.Ship)
+	if err != nil {
+		return err
+	}
+
+	factorOutput, err := openfactor.EvaluateFactor(factorInput, calibration)
+	if err != nil {
+		return err
+	}
+
+	sensitivities, err := openfactor.EvaluateSensitivity(factorInput, calibration)
+	if err != nil {
+		return err
+	}
+	// rank by relative impact, inputs that are currently zero are ranked by their absolute impact.
+	// inputs that are not applicable are listed last.
+	sort.SliceStable(sensitivities, func(i, j int) bool {
+		if sensitivities[i].NotApplicable != sensitivities[j].NotApplicable {
+			return sensitivities[j].NotApplicable
+		}
+		if sensitivities[i].Elasticity != sensitivities[j].Elasticity {
+			return math.Abs(sensitivities[i].Elasticity) > math.Abs(sensitivities[j].Elasticity)
+		}
+		return math.Abs(sensitivities[i].Derivative) > math.Abs(sensitivities[j].Derivative)
+	})
+
+	fmt.Fprintf(w, "ship '%s' (openfactor %s): tcc %.4f\n\n", ship, factorOutput.Version, factorOutput.TCC)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "rank\tinput\tvalue\tunit\ttcc per unit\ttcc % per 1%\t")
+	for i, sensitivity := range sensitivities {
+		if sensitivity.NotApplicable {
+			fmt.Fprintf(table, "%d\t%s\t%.2f\t%s\tn/a\tn/a\t\n",
+				i+1,
+				sensitivity.Input,
+				sensitivity.Value,
+				sensitivity.Unit,
+			)
+			continue
+		}
+		fmt.Fprintf(table, "%d\t%s\t%.2f\t%s\t%+.6f\t%+.4f\t\n",
+			i+1,
+			sensitivity.Input,
+			sensitivity.Value,
+			sensitivity.Unit,
+			sensitivity.Derivative,
+			sensitivity.Elasticity,
+		)
+	}
+	return table.Flush()
+}
@@ -57,6 +57,10 @@ type ShipConfigBaseSpec struct {
 	Source SHIP_BASE_SPEC_SOURCE `toml:"source" validate:"required"`
 	// ORCRefNo is the boat certificate identifier in ORC database
 	ORCRefNo string `toml:"orc_ref_no"`
+	// Rig specifies the rig of 'orc' sourced specs, which is not provided by the orc certificate (optional)
+	Rig ShipBaseSpecRig `toml:"rig"`
+	// CodeZero specifies the code zero area of 'orc' sourced specs in square meters (optional)
+	CodeZero float64 `toml:"code_zero"`
 }
 
 type SHIP_EXTRA_SPEC_SOURCE string
@@ -76,8 +80,8 @@ type ShipInfo struct {
 	Name string `toml:"name" validate:"required"`
 	// Class specifies the boat class/model (e.g. DEHLER 30 OD)
 	Class string `toml:"class"`
-	// Age is the vessel's construction year
-	Age string `toml:"age"`
+	// Age is the vessel's construction year (e.g. 2017)
+	Age string `toml:"age" validate:"omitempty,number"`
 	// Builder is the name of the boat manufacturer (e.g. DEHLER)
 	Builder string `toml:"builder"`
 	// Designer specifies who designed the boat (e.g. JUDEL/VROLIJK)
@@ -90,6 +94,10 @@ type ShipBaseSpec struct {
 	Dimension ShipBaseSpecDimension `toml:"dimension" validate:"required"`
 	// SailArea contains measurements for different sail types
 	SailArea ShipBaseSpecSailArea `toml:"sail_area" validate:"required"`
+	// Rig contains the rig attributes of the boat (optional)
+	Rig ShipBaseSpecRig `toml:"rig"`
+	// Tolerance specifies the relative measurement tolerance per openfactor input (optional, e.g. wsa = 0.1)
+	Tolerance map[string]float64 `toml:"tolerance"`
 }
 
 type ShipBaseSpecDimension struct {
@@ -118,6 +126,47 @@ type ShipBaseSpecSailArea struct {
 	AsymmetricSpinnaker float64 `toml:"asymmetric_spinnaker" validate:"required"`
 	// SymmetricSpinnaker specifies the area of the largest onboard symmetric spinnaker in square meters
 	SymmetricSpinnaker float64 `toml:"symmetric_spinnaker" validate:"required"`
+	// CodeZero specifies the area of the code zero in square meters (optional)
+	CodeZero float64 `toml:"code_zero"`
+}
+
+type SHIP_BASE_SPEC_RIG_TYPE string
+
+const (
+	SHIP_BASE_SPEC_RIG_FRACTIONAL SHIP_BASE_SPEC_RIG_TYPE = "fractional"
+	SHIP_BASE_SPEC_RIG_MASTHEAD   SHIP_BASE_SPEC_RIG_TYPE = "masthead"
+)
+
+type ShipBaseSpecRig struct {
+	// Type specifies the rig type [fractional; masthead;]
+	Type SHIP_BASE_SPEC_RIG_TYPE `toml:"type"`
+	// BowspritLength specifies the length of the bowsprit (measured from the bow) in meters
+	BowspritLength float64 `toml:"bowsprit_length"`
+	// Spreaders specifies the number of spreader sets
+	Spreaders int64 `toml:"spreaders"`
+	// FurlingMain specifies whether the main sail is a furling main (in-mast or in-boom)
+	FurlingMain bool `toml:"furling_main"`
+}
+
+// ShipSails specifies the toml representation of the ship sail configurations (optional file).
+type ShipSails struct {
+	// Configurations contains the named sail configurations the ship is raced with
+	Configurations []ShipSailsConfiguration `toml:"configuration" validate:"dive"`
+}
+
+type ShipSailsConfiguration struct {
+	// Name specifies the identifier of the configuration (e.g., 'club')
+	Name string `toml:"name" validate:"required"`
+	// Main specifies the area of the main sail in square meters (optional, defaults to the base spec)
+	Main *float64 `toml:"main"`
+	// Jib specifies the area of the largest onboard jib sail in square meters (optional, defaults to the base spec)
+	Jib *float64 `toml:"jib"`
+	// AsymmetricSpinnaker specifies the area of the largest onboard downwind sail in square meters (optional, defaults to the base spec)
+	AsymmetricSpinnaker *float64 `toml:"asymmetric_spinnaker"`
+	// SymmetricSpinnaker specifies the area of the largest onboard symmetric spinnaker in square meters (optional, defaults to the base spec)
+	SymmetricSpinnaker *float64 `toml:"symmetric_spinnaker"`
+	// CodeZero specifies the area of the code zero in square meters (optional, defaults to the base spec)
+	CodeZero *float64 `toml:"code_zero"`
 }
 
 // ShipExtraSpec specifies the toml representation of the ship extra specification.
@@ -146,21 +195,27 @@ const (
 	SHIP_EXTRA_SPEC_DESIGN_DAGGERBOARD SHIP_EXTRA_SPEC_DESIGN_STABILIZATION = "daggerboard"
 	SHIP_EXTRA_SPEC_DESIGN_CENTREBOARD SHIP_EXTRA_SPEC_DESIGN_STABILIZATION = "centreboard"
 	SHIP_EXTRA_SPEC_DESIGN_FOILS       SHIP_EXTRA_SPEC_DESIGN_STABILIZATION = "foils"
+	SHIP_EXTRA_SPEC_DESIGN_LIFTINGKEEL SHIP_EXTRA_SPEC_DESIGN_STABILIZATION = "liftingkeel"
+	SHIP_EXTRA_SPEC_DESIGN_SWINGKEEL   SHIP_EXTRA_SPEC_DESIGN_STABILIZATION = "swingkeel"
+	SHIP_EXTRA_SPEC_DESIGN_CANTINGKEEL SHIP_EXTRA_SPEC_DESIGN_STABILIZATION = "cantingkeel"
 )
 
 type SHIP_EXTRA_SPEC_DESIGN_HULL string
 
 const (
-	SHIP_EXTRA_SPEC_DESIGN_MONO  SHIP_EXTRA_SPEC_DESIGN_HULL = "mono"
-	SHIP_EXTRA_SPEC_DESIGN_MULTI SHIP_EXTRA_SPEC_DESIGN_HULL = "multi"
+	SHIP_EXTRA_SPEC_DESIGN_MONO      SHIP_EXTRA_SPEC_DESIGN_HULL = "mono"
+	SHIP_EXTRA_SPEC_DESIGN_MULTI     SHIP_EXTRA_SPEC_DESIGN_HULL = "multi"
+	SHIP_EXTRA_SPEC_DESIGN_CATAMARAN SHIP_EXTRA_SPEC_DESIGN_HULL = "catamaran"
+	SHIP_EXTRA_SPEC_DESIGN_TRIMARAN  SHIP_EXTRA_SPEC_DESIGN_HULL = "trimaran"
+	SHIP_EXTRA_SPEC_DESIGN_PROA      SHIP_EXTRA_SPEC_DESIGN_HULL = "proa"
 )
 
 type ShipExtraSpecDesign struct {
 	// Mode specifies the type of the hull design/mode [displace; semi; planing; hydrofoil;]
 	Mode SHIP_EXTRA_SPEC_DESIGN_MODE `toml:"mode"`
-	// Stabilization specifies the method used to stabilize the ship [foils; centreboard; daggerboard; keel;]
+	// Stabilization specifies the method used to stabilize the ship [foils; centreboard; daggerboard; finkeel; bulbkeel; fullkeel; liftingkeel; swingkeel; cantingkeel;]
 	Stabilization SHIP_EXTRA_SPEC_DESIGN_STABILIZATION `toml:"stabilization"`
-	// Hull specifies the hull type [mono; multi;]
+	// Hull specifies the hull type [mono; multi; catamaran; trimaran; proa;]
 	Hull SHIP_EXTRA_SPEC_DESIGN_HULL `toml:"hull"`
 }
 
@@ -37,4 +37,6 @@ type ShipStructure struct {
 	InfoFile      string
 	BaseSpecFile  string
 	ExtraSpecFile string
+	SailsFile     string
+	ORCFile       string
 }
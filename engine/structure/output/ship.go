@@ -27,6 +27,15 @@ type ShipConfig struct {
 	ShipBaseSpec  ShipConfigBaseSpec  `json:"boat_base_spec"`
 	ShipExtraSpec ShipConfigExtraSpec `json:"boat_extra_spec"`
 	ShipRating    ShipConfigRating    `json:"boat_rating"`
+	// ShipRatings contains the rating of every generated algorithm version (including boat_rating).
+	ShipRatings map[string]ShipConfigRating `json:"boat_ratings"`
+	// ShipSailConfigurations contains the rating of every declared sail configuration (keyed by name).
+	ShipSailConfigurations map[string]ShipConfigSailConfiguration `json:"boat_sail_configurations"`
+}
+
+type ShipConfigSailConfiguration struct {
+	SailArea ShipConfigBaseSpecSailArea `json:"sail_area"`
+	Rating   ShipConfigRating           `json:"rating"`
 }
 
 type SHIP_INFO_SOURCE string
@@ -56,6 +65,8 @@ type ShipConfigBaseSpec struct {
 	Source    SHIP_BASE_SPEC_SOURCE       `json:"source"`
 	Dimension ShipConfigBaseSpecDimension `json:"dimension"`
 	SailArea  ShipConfigBaseSpecSailArea  `json:"sail_area"`
+	Rig       ShipConfigBaseSpecRig       `json:"rig"`
+	Tolerance map[string]float64          `json:"tolerance"`
 }
 
 type ShipConfigBaseSpecDimension struct {
@@ -73,6 +84,21 @@ type ShipConfigBaseSpecSailArea struct {
 	Jib                 float64 `json:"jib"`
 	AsymmetricSpinnaker float64 `json:"asymmetric_spinnaker"`
 	SymmetricSpinnaker  float64 `json:"symmetric_spinnaker"`
+	CodeZero            float64 `json:"code_zero"`
+}
+
+type SHIP_BASE_SPEC_RIG_TYPE string
+
+const (
+	SHIP_BASE_SPEC_RIG_FRACTIONAL SHIP_BASE_SPEC_RIG_TYPE = "fractional"
+	SHIP_BASE_SPEC_RIG_MASTHEAD   SHIP_BASE_SPEC_RIG_TYPE = "masthead"
+)
+
+type ShipConfigBaseSpecRig struct {
+	Type           SHIP_BASE_SPEC_RIG_TYPE `json:"type"`
+	BowspritLength float64                 `json:"bowsprit_length"`
+	Spreaders      int64                   `json:"spreaders"`
+	FurlingMain    bool                    `json:"furling_main"`
 }
 
 type SHIP_EXTRA_SPEC_SOURCE string
@@ -105,13 +131,19 @@ const (
 	SHIP_EXTRA_SPEC_DESIGN_DAGGERBOARD SHIP_EXTRA_SPEC_DESIGN_STABILIZATION = "daggerboard"
 	SHIP_EXTRA_SPEC_DESIGN_CENTREBOARD SHIP_EXTRA_SPEC_DESIGN_STABILIZATION = "centreboard"
 	SHIP_EXTRA_SPEC_DESIGN_FOILS       SHIP_EXTRA_SPEC_DESIGN_STABILIZATION = "foils"
+	SHIP_EXTRA_SPEC_DESIGN_LIFTINGKEEL SHIP_EXTRA_SPEC_DESIGN_STABILIZATION = "liftingkeel"
+	SHIP_EXTRA_SPEC_DESIGN_SWINGKEEL   SHIP_EXTRA_SPEC_DESIGN_STABILIZATION = "swingkeel"
+	SHIP_EXTRA_SPEC_DESIGN_CANTINGKEEL SHIP_EXTRA_SPEC_DESIGN_STABILIZATION = "cantingkeel"
 )
 
 type SHIP_EXTRA_SPEC_DESIGN_HULL string
 
 const (
-	SHIP_EXTRA_SPEC_DESIGN_MONO  SHIP_EXTRA_SPEC_DESIGN_HULL = "manual"
-	SHIP_EXTRA_SPEC_DESIGN_MULTI SHIP_EXTRA_SPEC_DESIGN_HULL = "multi"
+	SHIP_EXTRA_SPEC_DESIGN_MONO      SHIP_EXTRA_SPEC_DESIGN_HULL = "manual"
+	SHIP_EXTRA_SPEC_DESIGN_MULTI     SHIP_EXTRA_SPEC_DESIGN_HULL = "multi"
+	SHIP_EXTRA_SPEC_DESIGN_CATAMARAN SHIP_EXTRA_SPEC_DESIGN_HULL = "catamaran"
+	SHIP_EXTRA_SPEC_DESIGN_TRIMARAN  SHIP_EXTRA_SPEC_DESIGN_HULL = "trimaran"
+	SHIP_EXTRA_SPEC_DESIGN_PROA      SHIP_EXTRA_SPEC_DESIGN_HULL = "proa"
 )
 
 type ShipConfigExtraSpecDesign struct {
@@ -139,6 +171,7 @@ type ShipConfigRating struct {
 	SpeedDragPoints     float64 `json:"speed_drag_points"`
 	SpeedUpwindPoints   float64 `json:"speed_upwind_points"`
 	SpeedDownwindPoints float64 `json:"speed_downwind_points"`
+	SpeedReachPoints    float64 `json:"speed_reach_points"`
 
 	StabilizationFactor    float64 `json:"stabilization_factor"`
 	StabilizationInfluence float64 `json:"stabilization_influence"`
@@ -147,4 +180,82 @@ type ShipConfigRating struct {
 	AgilityFactor    float64 `json:"agility_factor"`
 	AgilityInfluence float64 `json:"agility_influence"`
 	AgilityPoints    float64 `json:"agility_points"`
+
+	AgeAllowance float64 `json:"age_allowance"`
+
+	SecondsPerMile float64 `json:"seconds_per_mile"`
+	PLT            float64 `json:"plt"`
+	PLD            float64 `json:"pld"`
+
+	WindBands []ShipConfigRatingWindBand `json:"wind_bands"`
+	Courses   []ShipConfigRatingCourse   `json:"courses"`
+
+	ShortHanded []ShipConfigRatingShortHanded `json:"short_handed"`
+
+	// Confidence specifies how much the rating can be trusted.
+	Confidence ShipConfigConfidence `json:"confidence"`
+
+	Uncertainty *ShipConfigRatingUncertainty `json:"uncertainty"`
+
+	Trace []ShipConfigRatingTrace `json:"trace"`
+}
+
+type ShipConfigRatingWindBand struct {
+	Name         string  `json:"name"`
+	MinWindSpeed float64 `json:"min_wind_speed"`
+	MaxWindSpeed float64 `json:"max_wind_speed"`
+	TCC          float64 `json:"tcc"`
+}
+
+type ShipConfigRatingCourse struct {
+	Name     string  `json:"name"`
+	Upwind   float64 `json:"upwind"`
+	Reach    float64 `json:"reach"`
+	Downwind float64 `json:"downwind"`
+	TCC      float64 `json:"tcc"`
+}
+
+type ShipConfigRatingShortHanded struct {
+	CrewCount     int64   `json:"crew_count"`
+	Autopilot     bool    `json:"autopilot"`
+	TCC           float64 `json:"tcc"`
+	SpeedFactor   float64 `json:"speed_factor"`
+	AgilityFactor float64 `json:"agility_factor"`
+}
+
+type ShipConfigRatingUncertainty struct {
+	Samples         int     `json:"samples"`
+	Rejected        int     `json:"rejected"`
+	Median          float64 `json:"median"`
+	Lower           float64 `json:"lower"`
+	Upper           float64 `json:"upper"`
+	LowerPercentile float64 `json:"lower_percentile"`
+	UpperPercentile float64 `json:"upper_percentile"`
+}
+
+type ShipConfigRatingTrace struct {
+	Category string  `json:"category"`
+	Term     string  `json:"term"`
+	Value    float64 `json:"value"`
+}
+
+type ShipConfigConfidence struct {
+	Score    float64                       `json:"score"`
+	Review   bool                          `json:"review"`
+	Findings []ShipConfigConfidenceFinding `json:"findings"`
+}
+
+type SHIP_CONFIDENCE_CHECK string
+
+const (
+	SHIP_CONFIDENCE_CHECK_SOURCE  SHIP_CONFIDENCE_CHECK = "source"
+	SHIP_CONFIDENCE_CHECK_MISSING SHIP_CONFIDENCE_CHECK = "missing"
+	SHIP_CONFIDENCE_CHECK_OUTLIER SHIP_CONFIDENCE_CHECK = "outlier"
+)
+
+type ShipConfigConfidenceFinding struct {
+	Check   SHIP_CONFIDENCE_CHECK `json:"check"`
+	Field   string                `json:"field"`
+	Message string                `json:"message"`
+	Penalty float64               `json:"penalty"`
 }
@@ -0,0 +1,82 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/megakuul/opensail/engine/adapter/orc"
+)
+
+// orcCertificateValidator validates the live orc certificates referenced by updated ships.
+type orcCertificateValidator struct {
+	client *orc.Client
+	// families specifies the accepted certificate families.
+	families map[string]struct{}
+	// maxAge specifies the age after which a certificate is expired (0 disables the check).
+	maxAge time.Duration
+	// rejectExpired specifies whether expired certificates fail the validation (otherwise they are reported).
+	rejectExpired bool
+	// now specifies the reference time of the age check.
+	now time.Time
+	// report receives the warnings of the validation.
+	report io.Writer
+	// validated holds the RefNos that were already validated.
+	validated map[string]struct{}
+}
+
+// validateCertificate validates the live orc certificate of the RefNo.
+// Certificates referenced by both info and base spec are validated once.
+func (v *orcCertificateValidator) validateCertificate(ctx context.Context, refNo string) error {
+	if refNo == "" {
+		return fmt.Errorf("invalid ship orc RefNo. '%s'", refNo)
+	}
+	if _, ok := v.validated[refNo]; ok {
+		return nil
+	}
+
+	downBoatRms, err := v.client.GetDownBoatRMS(ctx, refNo)
+	if err != nil {
+		return err
+	}
+	certificate, err := downBoatRms.Certificate(refNo)
+	if err != nil {
+		return err
+	}
+
+	err = certificate.CheckFamily(v.families)
+	if err != nil {
+		return err
+	}
+
+	err = certificate.CheckAge(v.maxAge, v.now)
+	if errors.Is(err, orc.ErrExpiredCertificate) && !v.rejectExpired {
+		fmt.Fprintf(v.report, "warning: %v\n", err)
+	} else if err != nil {
+		return err
+	}
+
+	v.validated[refNo] = struct{}{}
+	return nil
+}
@@ -20,6 +20,8 @@
 package validate
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -27,12 +29,13 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/go-playground/validator/v10"
-	"github.com/megakuul/opensail/engine/adapter/orc"
+	"github.com/megakuul/opensail/engine/generate"
 	"github.com/megakuul/opensail/engine/structure/input"
+	"github.com/megakuul/opensail/openfactor"
 )
 
 // validateShips performs checks and validations on updated ship register entries.
-func validateShips(repoPath string, ships map[string]struct{}, shipStruct input.ShipStructure) error {
+func validateShips(ctx context.Context, orcValidator *orcCertificateValidator, repoPath string, ships map[string]struct{}, shipStruct input.ShipStructure) error {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	shipsPath := path.Join(repoPath, shipStruct.BasePath)
@@ -67,12 +70,38 @@ func validateShips(repoPath string, ships map[string]struct{}, shipStruct input.
 			return fmt.Errorf("ship identifier does not match the required format (e.g., 'sui_example')")
 		}
 
-		err = validateShipInfo(shipConfig.Info, path.Join(shipPath, shipStruct.InfoFile))
+		err = validateShipInfo(ctx, orcValidator, shipConfig.Info, path.Join(shipPath, shipStruct.InfoFile))
 		if err != nil {
 			return err
 		}
 
-		err = validateShipBaseSpec(shipConfig.BaseSpec, path.Join(shipPath, shipStruct.BaseSpecFile))
+		err = validateShipBaseSpec(ctx, orcValidator, shipConfig.BaseSpec, path.Join(shipPath, shipStruct.BaseSpecFile))
+		if err != nil {
+			return err
+		}
+
+		err = validateShipExtraSpec(shipConfig.ExtraSpec, path.Join(shipPath, shipStruct.ExtraSpecFile))
+		if err != nil {
+			return err
+		}
+
+		// the assembled input is checked against the openfactor plausibility ranges,
+		// so that implausible ships are rejected before they reach generate.
+		factorInput, err := generate.GenerateShipFactorInput(ctx, orcValidator.client, repoPath, ship, shipStruct)
+		if err != nil {
+			return err
+		}
+		err = openfactor.ValidateInput(factorInput, nil)
+		if err != nil {
+			return fmt.Errorf("implausible ship '%s': %w", ship, err)
+		}
+
+		err = validateShipSails(path.Join(shipPath, shipStruct.SailsFile), factorInput)
+		if err != nil {
+			return err
+		}
+
+		err = validateShipORCSnapshot(shipConfig, path.Join(shipPath, shipStruct.ORCFile))
 		if err != nil {
 			return err
 		}
@@ -81,7 +110,7 @@ func validateShips(repoPath string, ships map[string]struct{}, shipStruct input.
 	return nil
 }
 
-func validateShipInfo(info input.ShipConfigInfo, infoPath string) error {
+func validateShipInfo(ctx context.Context, orcValidator *orcCertificateValidator, info input.ShipConfigInfo, infoPath string) error {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	switch info.Source {
@@ -100,24 +129,17 @@ func validateShipInfo(info input.ShipConfigInfo, infoPath string) error {
 			return err
 		}
 	case input.SHIP_INFO_ORC:
-		if info.ORCRefNo == "" {
-			return fmt.Errorf("invalid ship orc RefNo. '%s'", info.ORCRefNo)
-		}
-		downBoatRms, err := orc.GetDownBoatRMS(info.ORCRefNo)
+		err := orcValidator.validateCertificate(ctx, info.ORCRefNo)
 		if err != nil {
 			return err
 		}
-
-		if len(downBoatRms.Rms) < 1 {
-			return fmt.Errorf("ship with RefNo. '%s' was not found on orc database", info.ORCRefNo)
-		}
 	default:
 		return fmt.Errorf("invalid ship info source '%s'", info.Source)
 	}
 	return nil
 }
 
-func validateShipBaseSpec(spec input.ShipConfigBaseSpec, specPath string) error {
+func validateShipBaseSpec(ctx context.Context, orcValidator *orcCertificateValidator, spec input.ShipConfigBaseSpec, specPath string) error {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	switch spec.Source {
@@ -135,17 +157,25 @@ func validateShipBaseSpec(spec input.ShipConfigBaseSpec, specPath string) error
 		if err != nil {
 			return err
 		}
-	case input.SHIP_BASE_SPEC_ORC:
-		if spec.ORCRefNo == "" {
-			return fmt.Errorf("invalid ship orc RefNo. '%s'", spec.ORCRefNo)
+
+		err = validateShipBaseSpecRig(shipSpec.Rig)
+		if err != nil {
+			return err
+		}
+
+		err = openfactor.ValidateTolerances(shipSpec.Tolerance)
+		if err != nil {
+			return fmt.Errorf("invalid ship base spec tolerance: %w", err)
 		}
-		downBoatRms, err := orc.GetDownBoatRMS(spec.ORCRefNo)
+	case input.SHIP_BASE_SPEC_ORC:
+		err := orcValidator.validateCertificate(ctx, spec.ORCRefNo)
 		if err != nil {
 			return err
 		}
 
-		if len(downBoatRms.Rms) < 1 {
-			return fmt.Errorf("ship with RefNo. '%s' was not found on orc database", spec.ORCRefNo)
+		err = validateShipBaseSpecRig(spec.Rig)
+		if err != nil {
+			return err
 		}
 	default:
 		return fmt.Errorf("invalid ship base spec source '%s'", spec.Source)
@@ -153,6 +183,18 @@ func validateShipBaseSpec(spec input.ShipConfigBaseSpec, specPath string) error
 	return nil
 }
 
+func validateShipBaseSpecRig(rig input.ShipBaseSpecRig) error {
+	switch rig.Type {
+	case "":
+	case input.SHIP_BASE_SPEC_RIG_FRACTIONAL:
+	case input.SHIP_BASE_SPEC_RIG_MASTHEAD:
+		break
+	default:
+		return fmt.Errorf("invalid ship base spec rig type '%s'", rig.Type)
+	}
+	return nil
+}
+
 func validateShipExtraSpec(spec input.ShipConfigExtraSpec, specPath string) error {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
@@ -189,6 +231,9 @@ func validateShipExtraSpec(spec input.ShipConfigExtraSpec, specPath string) erro
 		case input.SHIP_EXTRA_SPEC_DESIGN_CENTREBOARD:
 		case input.SHIP_EXTRA_SPEC_DESIGN_DAGGERBOARD:
 		case input.SHIP_EXTRA_SPEC_DESIGN_FOILS:
+		case input.SHIP_EXTRA_SPEC_DESIGN_LIFTINGKEEL:
+		case input.SHIP_EXTRA_SPEC_DESIGN_SWINGKEEL:
+		case input.SHIP_EXTRA_SPEC_DESIGN_CANTINGKEEL:
 			break
 		default:
 			return fmt.Errorf("invalid ship extra spec stabilization '%s'", shipSpec.Design.Stabilization)
@@ -197,6 +242,9 @@ func validateShipExtraSpec(spec input.ShipConfigExtraSpec, specPath string) erro
 		switch shipSpec.Design.Hull {
 		case input.SHIP_EXTRA_SPEC_DESIGN_MONO:
 		case input.SHIP_EXTRA_SPEC_DESIGN_MULTI:
+		case input.SHIP_EXTRA_SPEC_DESIGN_CATAMARAN:
+		case input.SHIP_EXTRA_SPEC_DESIGN_TRIMARAN:
+		case input.SHIP_EXTRA_SPEC_DESIGN_PROA:
 			break
 		default:
 			return fmt.Errorf("invalid ship extra spec hull '%s'", shipSpec.Design.Hull)
@@ -218,3 +266,84 @@ func validateShipExtraSpec(spec input.ShipConfigExtraSpec, specPath string) erro
 	}
 	return nil
 }
+
+// validateShipSails validates the optional sail configurations of the ship.
+// Every configuration is checked against the openfactor plausibility ranges, as it is rated separately.
+func validateShipSails(sailsPath string, factorInput *openfactor.EvaluationInput) error {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	sailsRaw, err := os.ReadFile(sailsPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	sails := &input.ShipSails{}
+	err = toml.Unmarshal(sailsRaw, sails)
+	if err != nil {
+		return err
+	}
+	err = validate.Struct(sails)
+	if err != nil {
+		return err
+	}
+
+	names := map[string]struct{}{}
+	for _, configuration := range sails.Configurations {
+		ok, err := regexp.MatchString("^[a-z0-9_]{1,20}$", configuration.Name)
+		if err != nil || !ok {
+			return fmt.Errorf("sail configuration name '%s' does not match the required format (e.g., 'no_spinnaker')", configuration.Name)
+		}
+		if _, ok := names[configuration.Name]; ok {
+			return fmt.Errorf("duplicate sail configuration '%s'", configuration.Name)
+		}
+		names[configuration.Name] = struct{}{}
+
+		for _, area := range []*float64{
+			configuration.Main,
+			configuration.Jib,
+			configuration.AsymmetricSpinnaker,
+			configuration.SymmetricSpinnaker,
+			configuration.CodeZero,
+		} {
+			if area != nil && *area < 0 {
+				return fmt.Errorf("invalid sail area '%g' in sail configuration '%s'", *area, configuration.Name)
+			}
+		}
+
+		err = openfactor.ValidateInput(generate.ApplySailConfiguration(factorInput, configuration), nil)
+		if err != nil {
+			return fmt.Errorf("implausible sail configuration '%s': %w", configuration.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateShipORCSnapshot validates the optional pinned orc certificates of the ship.
+func validateShipORCSnapshot(shipConfig *input.ShipConfig, snapshotPath string) error {
+	snapshot, err := generate.ReadShipORCSnapshot(snapshotPath)
+	if err != nil {
+		return err
+	} else if snapshot == nil {
+		return nil
+	}
+
+	refNos := map[string]struct{}{}
+	if shipConfig.Info.Source == input.SHIP_INFO_ORC {
+		refNos[shipConfig.Info.ORCRefNo] = struct{}{}
+	}
+	if shipConfig.BaseSpec.Source == input.SHIP_BASE_SPEC_ORC {
+		refNos[shipConfig.BaseSpec.ORCRefNo] = struct{}{}
+	}
+	pinned := map[string]struct{}{}
+	for _, rms := range snapshot.Rms {
+		if _, ok := refNos[rms.RefNo]; !ok {
+			return fmt.Errorf("pinned orc certificate RefNo. '%s' is not referenced by the ship", rms.RefNo)
+		}
+		if _, ok := pinned[rms.RefNo]; ok {
+			return fmt.Errorf("duplicate pinned orc certificate RefNo. '%s'", rms.RefNo)
+		}
+		pinned[rms.RefNo] = struct{}{}
+	}
+	return nil
+}
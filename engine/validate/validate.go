@@ -22,9 +22,15 @@ package validate
 import (
 	"context"
 	"fmt"
+	"io"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v67/github"
+	"github.com/megakuul/opensail/engine/adapter/orc"
+	"github.com/megakuul/opensail/engine/generate"
 	"github.com/megakuul/opensail/engine/structure/input"
 	"github.com/megakuul/opensail/engine/structure/output"
 	"github.com/spf13/cobra"
@@ -36,6 +42,11 @@ type validateFlags struct {
 	githubRepo     string
 	githubPrNumber int
 	githubToken    string
+
+	orc              generate.ORCFlags
+	orcCertFamilies  []string
+	orcCertMaxAge    time.Duration
+	orcRejectExpired bool
 }
 
 func NewValidateCmd(inputStruct *input.Structure, outputStruct *output.Structure) *cobra.Command {
@@ -46,7 +57,7 @@ func NewValidateCmd(inputStruct *input.Structure, outputStruct *output.Structure
 		Short:        "validate pull requests to the opensail register",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return Run(flags, inputStruct, outputStruct)
+			return Run(cmd.Context(), cmd.ErrOrStderr(), flags, inputStruct, outputStruct)
 		},
 	}
 
@@ -67,13 +78,29 @@ func NewValidateCmd(inputStruct *input.Structure, outputStruct *output.Structure
 		"", "specify the github api token",
 	)
 
+	generate.AddORCFlags(cmd.Flags(), &flags.orc)
+	cmd.Flags().StringSliceVar(&flags.orcCertFamilies, "orc-cert-families",
+		slices.Sorted(maps.Keys(orc.CERT_FAMILIES)), "specify the accepted orc certificate families",
+	)
+	cmd.Flags().DurationVar(&flags.orcCertMaxAge, "orc-cert-max-age",
+		orc.ORC_DEFAULT_CERT_MAX_AGE, "specify the age after which orc certificates are expired (0 disables the check)",
+	)
+	cmd.Flags().BoolVar(&flags.orcRejectExpired, "orc-reject-expired",
+		false, "reject expired orc certificates instead of reporting a warning",
+	)
+
 	return cmd
 }
 
-func Run(flags *validateFlags, inputStruct *input.Structure, outputStruct *output.Structure) error {
+func Run(ctx context.Context, w io.Writer, flags *validateFlags, inputStruct *input.Structure, outputStruct *output.Structure) error {
+	orcClient, err := generate.NewORCClient(&flags.orc)
+	if err != nil {
+		return err
+	}
+
 	client := github.NewClient(nil)
 	files, _, err := client.PullRequests.ListFiles(
-		context.TODO(),
+		ctx,
 		flags.githubOwner,
 		flags.githubRepo,
 		flags.githubPrNumber,
@@ -98,7 +125,19 @@ func Run(flags *validateFlags, inputStruct *input.Structure, outputStruct *outpu
 		return fmt.Errorf("failure while validating teams: %w", err)
 	}
 
-	err = validateShips(flags.inputPath, updatedShips, inputStruct.Ship)
+	orcValidator := &orcCertificateValidator{
+		client:        orcClient,
+		families:      map[string]struct{}{},
+		maxAge:        flags.orcCertMaxAge,
+		rejectExpired: flags.orcRejectExpired,
+		now:           time.Now(),
+		report:        w,
+		validated:     map[string]struct{}{},
+	}
+	for _, family := range flags.orcCertFamilies {
+		orcValidator.families[family] = struct{}{}
+	}
+	err = validateShips(ctx, orcValidator, flags.inputPath, updatedShips, inputStruct.Ship)
 	if err != nil {
 		return fmt.Errorf("failure while validating ships: %w", err)
 	}
@@ -0,0 +1,51 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import "math"
+
+// age allowance constants used by the DefaultCalibration.
+// the allowance is disabled by default (AGE_ALLOWANCE_PER_YEAR = 0).
+const (
+	AGE_ALLOWANCE_PER_YEAR    = 0    // relative TCC credit per year of age (e.g. 0.002 for 0.2% per year)
+	AGE_ALLOWANCE_GRACE_YEARS = 5    // number of years without credit, new ships don't suffer from wear
+	AGE_ALLOWANCE_MAXIMUM     = 0.05 // maximum relative TCC credit
+	AGE_REFERENCE_YEAR        = 2024 // year the age of the ships is measured against
+)
+
+// evaluateAgeAllowance calcs the relative TCC credit of the ship due to its age and wear.
+// Ships without construction year (0) don't receive an allowance.
+//
+// The allowance curve is linear: no credit during the grace years, then AgeAllowancePerYear
+// for each additional year, limited to AgeAllowanceMaximum.
+func evaluateAgeAllowance(c *Calibration, t *tracer, constructionYear int64) float64 {
+	if constructionYear <= 0 {
+		return 0
+	}
+	age := math.Max(float64(c.AgeReferenceYear-constructionYear), 0)
+	creditedYears := math.Max(age-c.AgeAllowanceGraceYears, 0)
+	allowance := math.Min(creditedYears*c.AgeAllowancePerYear, c.AgeAllowanceMaximum)
+
+	t.record(TRACE_ALLOWANCE, "age", age)
+	t.record(TRACE_ALLOWANCE, "age_credited_years", creditedYears)
+	t.record(TRACE_ALLOWANCE, "age_allowance", allowance)
+
+	return allowance
+}
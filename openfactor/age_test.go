@@ -0,0 +1,68 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import "testing"
+
+func TestEvaluateAgeAllowance(t *testing.T) {
+	c := DefaultCalibration()
+	c.AgeAllowancePerYear = 0.002
+	c.AgeAllowanceGraceYears = 5
+	c.AgeAllowanceMaximum = 0.05
+	c.AgeReferenceYear = 2024
+
+	tests := []struct {
+		name             string
+		constructionYear int64
+		allowance        float64
+	}{
+		{"unknown", 0, 0},
+		{"grace_years", 2021, 0},
+		{"end_of_grace_years", 2019, 0},
+		{"credited", 2009, 0.02},
+		{"maximum", 1950, 0.05},
+		{"after_reference_year", 2030, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertFloat(t, "age allowance", evaluateAgeAllowance(c, nil, test.constructionYear), test.allowance)
+		})
+	}
+}
+
+func TestEvaluateFactorAgeAllowance(t *testing.T) {
+	c := DefaultCalibration()
+	c.AgeAllowancePerYear = 0.002
+
+	// the gc32 (2012) is 12 years old, 7 years are credited.
+	input := newTestInput()
+	input.ConstructionYear = 2012
+	output, err := EvaluateFactor(input, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFloat(t, "age allowance", output.AgeAllowance, 0.014)
+	assertFloat(t, "tcc", output.TCC, 0.835*1.014)
+	for _, course := range output.Courses {
+		if course.Name == "windward_leeward" {
+			assertFloat(t, "windward_leeward tcc", course.TCC, 0.835*1.014)
+		}
+	}
+}
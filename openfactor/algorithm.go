@@ -0,0 +1,121 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Algorithm specifies a version of the rating algorithm.
+type Algorithm interface {
+	// Version returns the version string attached to the ratings of the algorithm.
+	Version() string
+	// Evaluate derives the rating of the ship.
+	Evaluate(input *EvaluationInput) (*EvaluationOutput, error)
+	// EvaluateUnrounded derives the factors and the TCC of the ship on unrounded points.
+	// It is used to sample small input changes, the integer points of Evaluate would quantize the response.
+	// Wind bands, courses and scoring coefficients are not derived.
+	EvaluateUnrounded(input *EvaluationInput) (*EvaluationOutput, error)
+}
+
+// calibratedAlgorithm evaluates the openfactor algorithm with a fixed calibration.
+type calibratedAlgorithm struct {
+	calibration *Calibration
+}
+
+// NewAlgorithm creates an algorithm evaluating the openfactor algorithm with the provided calibration.
+// If no calibration is provided, the DefaultCalibration is used.
+func NewAlgorithm(calibration *Calibration) Algorithm {
+	if calibration == nil {
+		calibration = DefaultCalibration()
+	}
+	return &calibratedAlgorithm{calibration: calibration}
+}
+
+func (a *calibratedAlgorithm) Version() string {
+	return a.calibration.Version
+}
+
+func (a *calibratedAlgorithm) Evaluate(input *EvaluationInput) (*EvaluationOutput, error) {
+	return EvaluateFactor(input, a.calibration)
+}
+
+func (a *calibratedAlgorithm) EvaluateUnrounded(input *EvaluationInput) (*EvaluationOutput, error) {
+	if err := ValidateInput(input, a.calibration); err != nil {
+		return nil, err
+	}
+	output := evaluateFactor(input, a.calibration, func(points float64) float64 {
+		return points
+	}, nil)
+	if err := validateOutput(output); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+var (
+	algorithmsLock = sync.RWMutex{}
+	algorithms     = map[string]Algorithm{}
+)
+
+func init() {
+	RegisterAlgorithm(NewAlgorithm(DefaultCalibration()))
+	RegisterAlgorithm(NewAlgorithm(CalibrationV2()))
+}
+
+// RegisterAlgorithm adds the algorithm to the registry of algorithm versions.
+// Registering a version twice results in an error.
+func RegisterAlgorithm(algorithm Algorithm) error {
+	algorithmsLock.Lock()
+	defer algorithmsLock.Unlock()
+
+	if _, ok := algorithms[algorithm.Version()]; ok {
+		return fmt.Errorf("openfactor version '%s' is already registered", algorithm.Version())
+	}
+	algorithms[algorithm.Version()] = algorithm
+	return nil
+}
+
+// LookupAlgorithm returns the registered algorithm of the version.
+func LookupAlgorithm(version string) (Algorithm, error) {
+	algorithmsLock.RLock()
+	defer algorithmsLock.RUnlock()
+
+	algorithm, ok := algorithms[version]
+	if !ok {
+		return nil, fmt.Errorf("unknown openfactor version '%s'", version)
+	}
+	return algorithm, nil
+}
+
+// Algorithms returns the sorted versions of all registered algorithms.
+func Algorithms() []string {
+	algorithmsLock.RLock()
+	defer algorithmsLock.RUnlock()
+
+	versions := []string{}
+	for version := range algorithms {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
@@ -0,0 +1,67 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import "testing"
+
+func TestLookupAlgorithm(t *testing.T) {
+	tests := []struct {
+		version string
+		tcc     float64
+		err     bool
+	}{
+		{"v0.0.1", 0.835, false},
+		{"v0.0.2", 0, false},
+		{"v9.9.9", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			algorithm, err := LookupAlgorithm(test.version)
+			if test.err {
+				if err == nil {
+					t.Fatal("expected an error for an unknown version")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if algorithm.Version() != test.version {
+				t.Errorf("unexpected version '%s'", algorithm.Version())
+			}
+			output, err := algorithm.Evaluate(newTestInput())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output.Version != test.version {
+				t.Errorf("unexpected output version '%s'", output.Version)
+			}
+			if test.tcc != 0 {
+				assertFloat(t, "tcc", output.TCC, test.tcc)
+			}
+		})
+	}
+}
+
+func TestRegisterAlgorithmTwice(t *testing.T) {
+	if err := RegisterAlgorithm(NewAlgorithm(CalibrationV2())); err == nil {
+		t.Fatal("expected an error for a registered version")
+	}
+}
@@ -0,0 +1,223 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	CALIBRATION_INITIAL_STEP       = 0.2   // initial relative step of the pattern search
+	CALIBRATION_MINIMUM_STEP       = 0.001 // relative step at which the pattern search is considered converged
+	CALIBRATION_MAXIMUM_ITERATIONS = 1000  // maximum number of pattern search iterations
+)
+
+// RaceResult specifies the elapsed time of a ship in a race.
+type RaceResult struct {
+	// Ship specifies the ship identifier.
+	Ship string
+	// Input specifies the evaluation input of the ship.
+	Input *EvaluationInput
+	// ElapsedTime specifies the elapsed time of the ship in seconds.
+	ElapsedTime float64
+}
+
+// Race specifies the results of a race sailed by the ships under the same conditions.
+type Race struct {
+	// Name specifies the race identifier.
+	Name string
+	// Results specifies the elapsed times of the ships that finished the race.
+	Results []RaceResult
+}
+
+// CalibrationStatistics specifies how well the corrected times of the races agree.
+// Corrected times are derived by dividing the elapsed time through the TCC, a perfect calibration
+// results in equal corrected times for every ship of a race.
+type CalibrationStatistics struct {
+	// Races specifies the number of races with at least two results.
+	Races int
+	// Results specifies the number of results in these races.
+	Results int
+	// MeanSpread specifies the mean coefficient of variation (in %) of the corrected times per race.
+	MeanSpread float64
+	// MaxSpread specifies the largest coefficient of variation (in %) of the corrected times of a race.
+	MaxSpread float64
+	// MeanDeviation specifies the mean absolute deviation (in %) of a corrected time from its race mean.
+	MeanDeviation float64
+}
+
+// CalibrationChange specifies the change of a calibration constant.
+type CalibrationChange struct {
+	Name   string
+	Before float64
+	After  float64
+}
+
+// CalibrationResult specifies the outcome of Calibrate.
+type CalibrationResult struct {
+	// Calibration specifies the proposed calibration.
+	Calibration *Calibration
+	// Before and After specify the statistics of the base and the proposed calibration.
+	Before CalibrationStatistics
+	After  CalibrationStatistics
+	// Changes specifies the optimized calibration constants.
+	Changes []CalibrationChange
+}
+
+// calibrationParameter specifies a calibration constant that is optimized by Calibrate.
+type calibrationParameter struct {
+	name  string
+	field func(c *Calibration) *float64
+}
+
+var calibrationParameters = []calibrationParameter{
+	{"speed_factor_influence", func(c *Calibration) *float64 { return &c.SpeedFactorInfluence }},
+	{"stabilization_factor_influence", func(c *Calibration) *float64 { return &c.StabilizationFactorInfluence }},
+	{"agility_factor_influence", func(c *Calibration) *float64 { return &c.AgilityFactorInfluence }},
+	{"drag_speed_point_patcher", func(c *Calibration) *float64 { return &c.DragSpeedPointPatcher }},
+	{"upwind_speed_point_patcher", func(c *Calibration) *float64 { return &c.UpwindSpeedPointPatcher }},
+	{"downwind_speed_point_patcher", func(c *Calibration) *float64 { return &c.DownwindSpeedPointPatcher }},
+	{"stabilization_point_patcher", func(c *Calibration) *float64 { return &c.StabilizationPointPatcher }},
+	{"agility_point_patcher", func(c *Calibration) *float64 { return &c.AgilityPointPatcher }},
+}
+
+// Calibrate fits the calibration constants to the race results by minimizing the mean spread of the corrected times.
+// The constants are optimized with a pattern search starting at the base calibration, constants are kept positive.
+// If no base calibration is provided, the DefaultCalibration is used.
+func Calibrate(races []Race, base *Calibration) (*CalibrationResult, error) {
+	if base == nil {
+		base = DefaultCalibration()
+	}
+	for _, race := range races {
+		for _, result := range race.Results {
+			if err := ValidateInput(result.Input, base); err != nil {
+				return nil, fmt.Errorf("invalid input of ship '%s' (race '%s'): %w", result.Ship, race.Name, err)
+			}
+			if result.ElapsedTime <= 0 || math.IsNaN(result.ElapsedTime) || math.IsInf(result.ElapsedTime, 0) {
+				return nil, fmt.Errorf("invalid elapsed time of ship '%s' (race '%s')", result.Ship, race.Name)
+			}
+		}
+	}
+
+	before := EvaluateCalibrationStatistics(races, base)
+	if before.Races < 1 {
+		return nil, fmt.Errorf("calibration requires at least one race with two results")
+	}
+
+	calibration := *base
+	objective := before.MeanSpread
+	step := CALIBRATION_INITIAL_STEP
+	for i := 0; i < CALIBRATION_MAXIMUM_ITERATIONS && step >= CALIBRATION_MINIMUM_STEP; i++ {
+		improved := false
+		for _, parameter := range calibrationParameters {
+			value := *parameter.field(&calibration)
+			bestValue := value
+			for _, candidate := range []float64{value * (1 + step), value * (1 - step)} {
+				if candidate <= 0 {
+					continue
+				}
+				*parameter.field(&calibration) = candidate
+				candidateObjective := EvaluateCalibrationStatistics(races, &calibration).MeanSpread
+				if candidateObjective < objective {
+					objective, bestValue, improved = candidateObjective, candidate, true
+				}
+			}
+			*parameter.field(&calibration) = bestValue
+		}
+		if !improved {
+			step /= 2
+		}
+	}
+
+	changes := []CalibrationChange{}
+	for _, parameter := range calibrationParameters {
+		changes = append(changes, CalibrationChange{
+			Name:   parameter.name,
+			Before: *parameter.field(base),
+			After:  *parameter.field(&calibration),
+		})
+	}
+
+	return &CalibrationResult{
+		Calibration: &calibration,
+		Before:      before,
+		After:       EvaluateCalibrationStatistics(races, &calibration),
+		Changes:     changes,
+	}, nil
+}
+
+// EvaluateCalibrationStatistics evaluates how well the corrected times of the races agree with the calibration.
+// Races with less than two results are ignored. The TCCs are evaluated on unrounded points, so that the
+// statistics respond to small calibration changes. If a TCC is not positive, the spread is infinite.
+func EvaluateCalibrationStatistics(races []Race, calibration *Calibration) CalibrationStatistics {
+	statistics := CalibrationStatistics{}
+	totalSpread, totalDeviation := 0.0, 0.0
+	for _, race := range races {
+		if len(race.Results) < 2 {
+			continue
+		}
+
+		correctedTimes := []float64{}
+		for _, result := range race.Results {
+			tcc := evaluateFactor(result.Input, calibration, func(points float64) float64 {
+				return points
+			}, nil).TCC
+			if tcc <= 0 || math.IsNaN(tcc) || math.IsInf(tcc, 0) {
+				return CalibrationStatistics{
+					Races:         statistics.Races,
+					Results:       statistics.Results,
+					MeanSpread:    math.Inf(1),
+					MaxSpread:     math.Inf(1),
+					MeanDeviation: math.Inf(1),
+				}
+			}
+			correctedTimes = append(correctedTimes, result.ElapsedTime/tcc)
+		}
+
+		mean := 0.0
+		for _, correctedTime := range correctedTimes {
+			mean += correctedTime
+		}
+		mean /= float64(len(correctedTimes))
+
+		variance, deviation := 0.0, 0.0
+		for _, correctedTime := range correctedTimes {
+			variance += math.Pow(correctedTime-mean, 2)
+			deviation += math.Abs(correctedTime - mean)
+		}
+		variance /= float64(len(correctedTimes))
+
+		spread := math.Sqrt(variance) / mean * 100
+		totalSpread += spread
+		totalDeviation += deviation / mean * 100
+		statistics.MaxSpread = math.Max(statistics.MaxSpread, spread)
+		statistics.Races++
+		statistics.Results += len(correctedTimes)
+	}
+
+	if statistics.Races > 0 {
+		statistics.MeanSpread = totalSpread / float64(statistics.Races)
+	}
+	if statistics.Results > 0 {
+		statistics.MeanDeviation = totalDeviation / float64(statistics.Results)
+	}
+	return statistics
+}
@@ -0,0 +1,113 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import (
+	"strings"
+	"testing"
+)
+
+// newTestRace returns a race of the example ships with the elapsed times expected by the calibration,
+// scaled by the factors (1 results in equal corrected times).
+func newTestRace(name string, calibration *Calibration, factors ...float64) Race {
+	race := Race{Name: name}
+	for i, input := range []*EvaluationInput{newTestInput(), newTestDinghyInput()} {
+		tcc := evaluateFactor(input, calibration, func(points float64) float64 { return points }, nil).TCC
+		race.Results = append(race.Results, RaceResult{
+			Ship:        []string{"gc32", "hobie"}[i],
+			Input:       input,
+			ElapsedTime: 3600 * tcc * factors[i],
+		})
+	}
+	return race
+}
+
+func TestEvaluateCalibrationStatistics(t *testing.T) {
+	c := DefaultCalibration()
+	tests := []struct {
+		name      string
+		races     []Race
+		raceCount int
+		results   int
+		spread    float64
+		deviation float64
+	}{
+		{"equal_corrected_times", []Race{newTestRace("equal", c, 1, 1)}, 1, 2, 0, 0},
+		// corrected times 100 and 110 (relative): standard deviation 5 around the mean 105.
+		{"spread", []Race{newTestRace("spread", c, 1, 1.1)}, 1, 2, 5.0 / 1.05, 5.0 / 1.05},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statistics := EvaluateCalibrationStatistics(test.races, c)
+			if statistics.Races != test.raceCount || statistics.Results != test.results {
+				t.Errorf("expected %d races with %d results, got %d races with %d results", test.raceCount, test.results, statistics.Races, statistics.Results)
+			}
+			assertFloat(t, "mean spread", statistics.MeanSpread, test.spread)
+			assertFloat(t, "max spread", statistics.MaxSpread, test.spread)
+			assertFloat(t, "mean deviation", statistics.MeanDeviation, test.deviation)
+		})
+	}
+}
+
+func TestCalibrate(t *testing.T) {
+	// the hobie is consistently 10% slower than rated, the calibration must reduce the spread.
+	races := []Race{
+		newTestRace("race_1", DefaultCalibration(), 1, 1.1),
+		newTestRace("race_2", DefaultCalibration(), 1, 1.1),
+	}
+	result, err := Calibrate(races, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFloat(t, "spread before", result.Before.MeanSpread, 5.0/1.05)
+	if result.After.MeanSpread >= result.Before.MeanSpread {
+		t.Errorf("expected the calibration to reduce the spread, got %v -> %v", result.Before.MeanSpread, result.After.MeanSpread)
+	}
+	if len(result.Changes) != len(calibrationParameters) {
+		t.Fatalf("expected %d changes, got %d", len(calibrationParameters), len(result.Changes))
+	}
+	for i, change := range result.Changes {
+		if change.Name != calibrationParameters[i].name || change.After <= 0 {
+			t.Errorf("unexpected change %+v", change)
+		}
+	}
+}
+
+func TestCalibrateErrors(t *testing.T) {
+	invalidTime := newTestRace("invalid_time", DefaultCalibration(), 1, 1)
+	invalidTime.Results[1].ElapsedTime = 0
+
+	tests := []struct {
+		name    string
+		races   []Race
+		message string
+	}{
+		{"no_races", nil, "calibration requires at least one race with two results"},
+		{"invalid_elapsed_time", []Race{invalidTime}, "invalid elapsed time of ship 'hobie' (race 'invalid_time')"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Calibrate(test.races, nil)
+			if err == nil || !strings.Contains(err.Error(), test.message) {
+				t.Fatalf("expected error '%s', got %v", test.message, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,556 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Calibration specifies the empirical constants used to derive the rating.
+type Calibration struct {
+	// Version specifies the version string attached to ratings derived with this calibration.
+	Version string
+
+	PointAnchor float64
+
+	SpeedFactorInfluence         float64
+	StabilizationFactorInfluence float64
+	AgilityFactorInfluence       float64
+
+	DragSpeedPointPatcher     float64
+	UpwindSpeedPointPatcher   float64
+	DownwindSpeedPointPatcher float64
+	ReachSpeedPointPatcher    float64
+	StabilizationPointPatcher float64
+	AgilityPointPatcher       float64
+
+	DragImpactScale         float64
+	DragPointNormalizer     float64
+	SailPowerNormalizer     float64
+	StabilizationPointScale float64
+	AgilityPointScale       float64
+
+	// AreaExponent and VolumeExponent specify the exponents converting areas and volumes into edge lengths.
+	AreaExponent   float64
+	VolumeExponent float64
+
+	ModeDragFactor                   map[MODE]float64
+	StabilizationStabilizationFactor map[STABILIZATION]float64
+	StabilizationAgilityFactor       map[STABILIZATION]float64
+	HullStabilizationFactor          map[HULL]float64
+	HullAgilityFactor                map[HULL]float64
+
+	MaterialStiffnessFactor    map[MATERIAL]float64
+	MaterialDistributionFactor map[MATERIAL]float64
+	MaterialDragFactor         map[MATERIAL]float64
+
+	RigUpwindFactor        map[RIG]float64
+	RigDownwindFactor      map[RIG]float64
+	BowspritDownwindFactor float64
+	CodeZeroUpwindShare    float64
+	SpreaderUpwindFactor   float64
+	FurlingMainFactor      float64
+
+	CrewMemberWeight         float64
+	ShortHandedAgilityFactor float64
+	ShortHandedSpeedFactor   float64
+	AutopilotRecovery        float64
+
+	ReferenceSecondsPerMile float64
+	ReferenceWindSpeed      float64
+
+	AgeAllowancePerYear    float64
+	AgeAllowanceGraceYears float64
+	AgeAllowanceMaximum    float64
+	AgeReferenceYear       int64
+
+	// WindBands specifies the wind ranges that receive a dedicated TCC.
+	WindBands []WindBand
+	// Courses specifies the course profiles that receive a dedicated TCC.
+	Courses []CourseProfile
+}
+
+// DefaultCalibration returns the built-in calibration of the algorithm.
+func DefaultCalibration() *Calibration {
+	return &Calibration{
+		Version: Version(),
+
+		PointAnchor: POINT_ANCHOR,
+
+		SpeedFactorInfluence:         SPEED_FACTOR_INFLUENCE,
+		StabilizationFactorInfluence: STABILIZATION_FACTOR_INFLUENCE,
+		AgilityFactorInfluence:       AGILITY_FACTOR_INFLUENCE,
+
+		DragSpeedPointPatcher:     DRAG_SPEED_POINT_PATCHER,
+		UpwindSpeedPointPatcher:   UPWIND_SPEED_POINT_PATCHER,
+		DownwindSpeedPointPatcher: DOWNWIND_SPEED_POINT_PATCHER,
+		ReachSpeedPointPatcher:    REACH_SPEED_POINT_PATCHER,
+		StabilizationPointPatcher: STABILIZATION_POINT_PATCHER,
+		AgilityPointPatcher:       AGILITY_POINT_PATCHER,
+
+		DragImpactScale:         DRAG_IMPACT_SCALE,
+		DragPointNormalizer:     DRAG_POINT_NORMALIZER,
+		SailPowerNormalizer:     SAIL_POWER_NORMALIZER,
+		StabilizationPointScale: STABILIZATION_POINT_SCALE,
+		AgilityPointScale:       AGILITY_POINT_SCALE,
+
+		AreaExponent:   AREA_EXPONENT,
+		VolumeExponent: VOLUME_EXPONENT,
+
+		ModeDragFactor:                   maps.Clone(MODE_DRAG_FACTOR),
+		StabilizationStabilizationFactor: maps.Clone(STABILIZATION_STABILIZATION_FACTOR),
+		StabilizationAgilityFactor:       maps.Clone(STABILIZATION_AGILITY_FACTOR),
+		HullStabilizationFactor:          maps.Clone(HULL_STABILIZATION_FACTOR),
+		HullAgilityFactor:                maps.Clone(HULL_AGILITY_FACTOR),
+
+		// the material weighting is introduced with v0.0.2, v0.0.1 ratings are not weighted by the composition.
+		MaterialStiffnessFactor:    newNeutralMaterialTable(1),
+		MaterialDistributionFactor: newNeutralMaterialTable(1),
+		MaterialDragFactor:         newNeutralMaterialTable(0),
+
+		RigUpwindFactor:        maps.Clone(RIG_UPWIND_FACTOR),
+		RigDownwindFactor:      maps.Clone(RIG_DOWNWIND_FACTOR),
+		BowspritDownwindFactor: BOWSPRIT_DOWNWIND_FACTOR,
+		CodeZeroUpwindShare:    CODE_ZERO_UPWIND_SHARE,
+		SpreaderUpwindFactor:   SPREADER_UPWIND_FACTOR,
+		FurlingMainFactor:      FURLING_MAIN_FACTOR,
+
+		CrewMemberWeight:         CREW_MEMBER_WEIGHT,
+		ShortHandedAgilityFactor: SHORT_HANDED_AGILITY_FACTOR,
+		ShortHandedSpeedFactor:   SHORT_HANDED_SPEED_FACTOR,
+		AutopilotRecovery:        AUTOPILOT_RECOVERY,
+
+		ReferenceSecondsPerMile: REFERENCE_SECONDS_PER_MILE,
+		ReferenceWindSpeed:      REFERENCE_WIND_SPEED,
+
+		AgeAllowancePerYear:    AGE_ALLOWANCE_PER_YEAR,
+		AgeAllowanceGraceYears: AGE_ALLOWANCE_GRACE_YEARS,
+		AgeAllowanceMaximum:    AGE_ALLOWANCE_MAXIMUM,
+		AgeReferenceYear:       AGE_REFERENCE_YEAR,
+
+		WindBands: cloneWindBands(WIND_BANDS),
+		Courses:   slices.Clone(COURSE_PROFILES),
+	}
+}
+
+// calibrationFile specifies the toml representation of the calibration.
+// Factor tables are keyed by the enum names (e.g. 'hydrofoil' or 'bulbkeel').
+type calibrationFile struct {
+	Version     string `toml:"version"`
+	BaseVersion string `toml:"base_version,omitempty"`
+
+	PointAnchor float64 `toml:"point_anchor"`
+
+	SpeedFactorInfluence         float64 `toml:"speed_factor_influence"`
+	StabilizationFactorInfluence float64 `toml:"stabilization_factor_influence"`
+	AgilityFactorInfluence       float64 `toml:"agility_factor_influence"`
+
+	DragSpeedPointPatcher     float64 `toml:"drag_speed_point_patcher"`
+	UpwindSpeedPointPatcher   float64 `toml:"upwind_speed_point_patcher"`
+	DownwindSpeedPointPatcher float64 `toml:"downwind_speed_point_patcher"`
+	ReachSpeedPointPatcher    float64 `toml:"reach_speed_point_patcher"`
+	StabilizationPointPatcher float64 `toml:"stabilization_point_patcher"`
+	AgilityPointPatcher       float64 `toml:"agility_point_patcher"`
+
+	DragImpactScale         float64 `toml:"drag_impact_scale"`
+	DragPointNormalizer     float64 `toml:"drag_point_normalizer"`
+	SailPowerNormalizer     float64 `toml:"sail_power_normalizer"`
+	StabilizationPointScale float64 `toml:"stabilization_point_scale"`
+	AgilityPointScale       float64 `toml:"agility_point_scale"`
+
+	AreaExponent   float64 `toml:"area_exponent"`
+	VolumeExponent float64 `toml:"volume_exponent"`
+
+	ModeDragFactor                   map[string]float64 `toml:"mode_drag_factor"`
+	StabilizationStabilizationFactor map[string]float64 `toml:"stabilization_stabilization_factor"`
+	StabilizationAgilityFactor       map[string]float64 `toml:"stabilization_agility_factor"`
+	HullStabilizationFactor          map[string]float64 `toml:"hull_stabilization_factor"`
+	HullAgilityFactor                map[string]float64 `toml:"hull_agility_factor"`
+
+	MaterialStiffnessFactor    map[string]float64 `toml:"material_stiffness_factor"`
+	MaterialDistributionFactor map[string]float64 `toml:"material_distribution_factor"`
+	MaterialDragFactor         map[string]float64 `toml:"material_drag_factor"`
+
+	RigUpwindFactor        map[string]float64 `toml:"rig_upwind_factor"`
+	RigDownwindFactor      map[string]float64 `toml:"rig_downwind_factor"`
+	BowspritDownwindFactor float64            `toml:"bowsprit_downwind_factor"`
+	CodeZeroUpwindShare    float64            `toml:"code_zero_upwind_share"`
+	SpreaderUpwindFactor   float64            `toml:"spreader_upwind_factor"`
+	FurlingMainFactor      float64            `toml:"furling_main_factor"`
+
+	CrewMemberWeight         float64 `toml:"crew_member_weight"`
+	ShortHandedAgilityFactor float64 `toml:"short_handed_agility_factor"`
+	ShortHandedSpeedFactor   float64 `toml:"short_handed_speed_factor"`
+	AutopilotRecovery        float64 `toml:"autopilot_recovery"`
+
+	ReferenceSecondsPerMile float64 `toml:"reference_seconds_per_mile"`
+	ReferenceWindSpeed      float64 `toml:"reference_wind_speed"`
+
+	AgeAllowancePerYear    float64 `toml:"age_allowance_per_year"`
+	AgeAllowanceGraceYears float64 `toml:"age_allowance_grace_years"`
+	AgeAllowanceMaximum    float64 `toml:"age_allowance_maximum"`
+	AgeReferenceYear       int64   `toml:"age_reference_year"`
+
+	WindBands []windBandFile `toml:"wind_band"`
+	Courses   []courseFile   `toml:"course"`
+}
+
+// courseFile specifies the toml representation of a course profile.
+type courseFile struct {
+	Name     string  `toml:"name"`
+	Upwind   float64 `toml:"upwind"`
+	Reach    float64 `toml:"reach"`
+	Downwind float64 `toml:"downwind"`
+}
+
+// windBandFile specifies the toml representation of a wind band.
+// Unspecified factors are taken from the top level calibration values.
+type windBandFile struct {
+	Name         string  `toml:"name"`
+	MinWindSpeed float64 `toml:"min_wind_speed"`
+	MaxWindSpeed float64 `toml:"max_wind_speed"`
+
+	ModeDragFactor               map[string]float64 `toml:"mode_drag_factor"`
+	StabilizationFactorInfluence *float64           `toml:"stabilization_factor_influence"`
+}
+
+// ParseCalibration parses a toml calibration file.
+// Values that are not specified in the file are taken from the built-in calibration selected with
+// 'base_version' (the DefaultCalibration if no base version is specified).
+// Wind bands and courses specified in the file replace the default wind bands and courses.
+func ParseCalibration(raw []byte) (*Calibration, error) {
+	base := &struct {
+		BaseVersion string `toml:"base_version"`
+	}{}
+	if _, err := toml.Decode(string(raw), base); err != nil {
+		return nil, err
+	}
+	defaultCalibration := DefaultCalibration()
+	if base.BaseVersion != "" {
+		var err error
+		defaultCalibration, err = BuiltinCalibration(base.BaseVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	file := newCalibrationFile(defaultCalibration)
+	file.WindBands = nil
+	file.Courses = nil
+	meta, err := toml.Decode(string(raw), file)
+	if err != nil {
+		return nil, err
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("unknown calibration key '%s'", undecoded[0])
+	}
+	if file.Version == "" {
+		return nil, fmt.Errorf("calibration version must not be empty")
+	}
+
+	calibration := &Calibration{
+		Version: file.Version,
+
+		PointAnchor: file.PointAnchor,
+
+		SpeedFactorInfluence:         file.SpeedFactorInfluence,
+		StabilizationFactorInfluence: file.StabilizationFactorInfluence,
+		AgilityFactorInfluence:       file.AgilityFactorInfluence,
+
+		DragSpeedPointPatcher:     file.DragSpeedPointPatcher,
+		UpwindSpeedPointPatcher:   file.UpwindSpeedPointPatcher,
+		DownwindSpeedPointPatcher: file.DownwindSpeedPointPatcher,
+		ReachSpeedPointPatcher:    file.ReachSpeedPointPatcher,
+		StabilizationPointPatcher: file.StabilizationPointPatcher,
+		AgilityPointPatcher:       file.AgilityPointPatcher,
+
+		DragImpactScale:         file.DragImpactScale,
+		DragPointNormalizer:     file.DragPointNormalizer,
+		SailPowerNormalizer:     file.SailPowerNormalizer,
+		StabilizationPointScale: file.StabilizationPointScale,
+		AgilityPointScale:       file.AgilityPointScale,
+
+		AreaExponent:   file.AreaExponent,
+		VolumeExponent: file.VolumeExponent,
+
+		BowspritDownwindFactor: file.BowspritDownwindFactor,
+		CodeZeroUpwindShare:    file.CodeZeroUpwindShare,
+		SpreaderUpwindFactor:   file.SpreaderUpwindFactor,
+		FurlingMainFactor:      file.FurlingMainFactor,
+
+		CrewMemberWeight:         file.CrewMemberWeight,
+		ShortHandedAgilityFactor: file.ShortHandedAgilityFactor,
+		ShortHandedSpeedFactor:   file.ShortHandedSpeedFactor,
+		AutopilotRecovery:        file.AutopilotRecovery,
+
+		ReferenceSecondsPerMile: file.ReferenceSecondsPerMile,
+		ReferenceWindSpeed:      file.ReferenceWindSpeed,
+
+		AgeAllowancePerYear:    file.AgeAllowancePerYear,
+		AgeAllowanceGraceYears: file.AgeAllowanceGraceYears,
+		AgeAllowanceMaximum:    file.AgeAllowanceMaximum,
+		AgeReferenceYear:       file.AgeReferenceYear,
+	}
+
+	calibration.ModeDragFactor, err = parseFactorTable("mode_drag_factor", file.ModeDragFactor, MODE_NAMES)
+	if err != nil {
+		return nil, err
+	}
+	calibration.StabilizationStabilizationFactor, err = parseFactorTable("stabilization_stabilization_factor", file.StabilizationStabilizationFactor, STABILIZATION_NAMES)
+	if err != nil {
+		return nil, err
+	}
+	calibration.StabilizationAgilityFactor, err = parseFactorTable("stabilization_agility_factor", file.StabilizationAgilityFactor, STABILIZATION_NAMES)
+	if err != nil {
+		return nil, err
+	}
+	calibration.HullStabilizationFactor, err = parseFactorTable("hull_stabilization_factor", file.HullStabilizationFactor, HULL_NAMES)
+	if err != nil {
+		return nil, err
+	}
+	calibration.HullAgilityFactor, err = parseFactorTable("hull_agility_factor", file.HullAgilityFactor, HULL_NAMES)
+	if err != nil {
+		return nil, err
+	}
+	calibration.MaterialStiffnessFactor, err = parseFactorTable("material_stiffness_factor", file.MaterialStiffnessFactor, MATERIAL_NAMES)
+	if err != nil {
+		return nil, err
+	}
+	calibration.MaterialDistributionFactor, err = parseFactorTable("material_distribution_factor", file.MaterialDistributionFactor, MATERIAL_NAMES)
+	if err != nil {
+		return nil, err
+	}
+	calibration.MaterialDragFactor, err = parseFactorTable("material_drag_factor", file.MaterialDragFactor, MATERIAL_NAMES)
+	if err != nil {
+		return nil, err
+	}
+	calibration.RigUpwindFactor, err = parseFactorTable("rig_upwind_factor", file.RigUpwindFactor, RIG_NAMES)
+	if err != nil {
+		return nil, err
+	}
+	calibration.RigDownwindFactor, err = parseFactorTable("rig_downwind_factor", file.RigDownwindFactor, RIG_NAMES)
+	if err != nil {
+		return nil, err
+	}
+
+	if !meta.IsDefined("wind_band") {
+		calibration.WindBands = defaultCalibration.WindBands
+	}
+	for _, bandFile := range file.WindBands {
+		if bandFile.Name == "" {
+			return nil, fmt.Errorf("calibration wind band name must not be empty")
+		}
+		if bandFile.MinWindSpeed >= bandFile.MaxWindSpeed {
+			return nil, fmt.Errorf("invalid range of calibration wind band '%s'", bandFile.Name)
+		}
+		band := WindBand{
+			Name:                         bandFile.Name,
+			MinWindSpeed:                 bandFile.MinWindSpeed,
+			MaxWindSpeed:                 bandFile.MaxWindSpeed,
+			ModeDragFactor:               maps.Clone(calibration.ModeDragFactor),
+			StabilizationFactorInfluence: calibration.StabilizationFactorInfluence,
+		}
+		bandDragFactor, err := parseFactorTable("wind_band.mode_drag_factor", bandFile.ModeDragFactor, MODE_NAMES)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(band.ModeDragFactor, bandDragFactor)
+		if bandFile.StabilizationFactorInfluence != nil {
+			band.StabilizationFactorInfluence = *bandFile.StabilizationFactorInfluence
+		}
+		calibration.WindBands = append(calibration.WindBands, band)
+	}
+
+	if !meta.IsDefined("course") {
+		calibration.Courses = defaultCalibration.Courses
+	}
+	for _, courseFile := range file.Courses {
+		if courseFile.Name == "" {
+			return nil, fmt.Errorf("calibration course name must not be empty")
+		}
+		if courseFile.Upwind < 0 || courseFile.Reach < 0 || courseFile.Downwind < 0 ||
+			courseFile.Upwind+courseFile.Reach+courseFile.Downwind <= 0 {
+			return nil, fmt.Errorf("invalid proportions of calibration course '%s'", courseFile.Name)
+		}
+		calibration.Courses = append(calibration.Courses, CourseProfile{
+			Name:     courseFile.Name,
+			Upwind:   courseFile.Upwind,
+			Reach:    courseFile.Reach,
+			Downwind: courseFile.Downwind,
+		})
+	}
+
+	return calibration, nil
+}
+
+// EncodeCalibration encodes the calibration into a toml calibration file.
+// The file specifies every value of the calibration, it does therefore not depend on a base version.
+func EncodeCalibration(calibration *Calibration) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	if err := toml.NewEncoder(buffer).Encode(newCalibrationFile(calibration)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// BuiltinCalibration returns the built-in calibration of the algorithm version.
+func BuiltinCalibration(version string) (*Calibration, error) {
+	switch version {
+	case Version():
+		return DefaultCalibration(), nil
+	case VERSION_V2:
+		return CalibrationV2(), nil
+	default:
+		return nil, fmt.Errorf("unknown openfactor version '%s'", version)
+	}
+}
+
+// newCalibrationFile converts the calibration into its toml representation.
+func newCalibrationFile(c *Calibration) *calibrationFile {
+	return &calibrationFile{
+		Version: c.Version,
+
+		PointAnchor: c.PointAnchor,
+
+		SpeedFactorInfluence:         c.SpeedFactorInfluence,
+		StabilizationFactorInfluence: c.StabilizationFactorInfluence,
+		AgilityFactorInfluence:       c.AgilityFactorInfluence,
+
+		DragSpeedPointPatcher:     c.DragSpeedPointPatcher,
+		UpwindSpeedPointPatcher:   c.UpwindSpeedPointPatcher,
+		DownwindSpeedPointPatcher: c.DownwindSpeedPointPatcher,
+		ReachSpeedPointPatcher:    c.ReachSpeedPointPatcher,
+		StabilizationPointPatcher: c.StabilizationPointPatcher,
+		AgilityPointPatcher:       c.AgilityPointPatcher,
+
+		DragImpactScale:         c.DragImpactScale,
+		DragPointNormalizer:     c.DragPointNormalizer,
+		SailPowerNormalizer:     c.SailPowerNormalizer,
+		StabilizationPointScale: c.StabilizationPointScale,
+		AgilityPointScale:       c.AgilityPointScale,
+
+		AreaExponent:   c.AreaExponent,
+		VolumeExponent: c.VolumeExponent,
+
+		ModeDragFactor:                   newFactorTable(c.ModeDragFactor, MODE_NAMES),
+		StabilizationStabilizationFactor: newFactorTable(c.StabilizationStabilizationFactor, STABILIZATION_NAMES),
+		StabilizationAgilityFactor:       newFactorTable(c.StabilizationAgilityFactor, STABILIZATION_NAMES),
+		HullStabilizationFactor:          newFactorTable(c.HullStabilizationFactor, HULL_NAMES),
+		HullAgilityFactor:                newFactorTable(c.HullAgilityFactor, HULL_NAMES),
+
+		MaterialStiffnessFactor:    newFactorTable(c.MaterialStiffnessFactor, MATERIAL_NAMES),
+		MaterialDistributionFactor: newFactorTable(c.MaterialDistributionFactor, MATERIAL_NAMES),
+		MaterialDragFactor:         newFactorTable(c.MaterialDragFactor, MATERIAL_NAMES),
+
+		RigUpwindFactor:        newFactorTable(c.RigUpwindFactor, RIG_NAMES),
+		RigDownwindFactor:      newFactorTable(c.RigDownwindFactor, RIG_NAMES),
+		BowspritDownwindFactor: c.BowspritDownwindFactor,
+		CodeZeroUpwindShare:    c.CodeZeroUpwindShare,
+		SpreaderUpwindFactor:   c.SpreaderUpwindFactor,
+		FurlingMainFactor:      c.FurlingMainFactor,
+
+		CrewMemberWeight:         c.CrewMemberWeight,
+		ShortHandedAgilityFactor: c.ShortHandedAgilityFactor,
+		ShortHandedSpeedFactor:   c.ShortHandedSpeedFactor,
+		AutopilotRecovery:        c.AutopilotRecovery,
+
+		ReferenceSecondsPerMile: c.ReferenceSecondsPerMile,
+		ReferenceWindSpeed:      c.ReferenceWindSpeed,
+
+		AgeAllowancePerYear:    c.AgeAllowancePerYear,
+		AgeAllowanceGraceYears: c.AgeAllowanceGraceYears,
+		AgeAllowanceMaximum:    c.AgeAllowanceMaximum,
+		AgeReferenceYear:       c.AgeReferenceYear,
+
+		WindBands: newWindBandFiles(c.WindBands),
+		Courses:   newCourseFiles(c.Courses),
+	}
+}
+
+// newCourseFiles converts the course profiles into their toml representation.
+func newCourseFiles(courses []CourseProfile) []courseFile {
+	files := []courseFile{}
+	for _, course := range courses {
+		files = append(files, courseFile{
+			Name:     course.Name,
+			Upwind:   course.Upwind,
+			Reach:    course.Reach,
+			Downwind: course.Downwind,
+		})
+	}
+	return files
+}
+
+// newWindBandFiles converts the wind bands into their toml representation.
+func newWindBandFiles(bands []WindBand) []windBandFile {
+	files := []windBandFile{}
+	for _, band := range bands {
+		files = append(files, windBandFile{
+			Name:                         band.Name,
+			MinWindSpeed:                 band.MinWindSpeed,
+			MaxWindSpeed:                 band.MaxWindSpeed,
+			ModeDragFactor:               newFactorTable(band.ModeDragFactor, MODE_NAMES),
+			StabilizationFactorInfluence: &band.StabilizationFactorInfluence,
+		})
+	}
+	return files
+}
+
+// newFactorTable converts an enum keyed factor map into a name keyed factor table.
+func newFactorTable[K comparable](factors map[K]float64, names map[K]string) map[string]float64 {
+	table := map[string]float64{}
+	for key, factor := range factors {
+		table[names[key]] = factor
+	}
+	return table
+}
+
+// parseFactorTable converts a name keyed factor table into an enum keyed factor map.
+func parseFactorTable[K comparable](tableName string, table map[string]float64, names map[K]string) (map[K]float64, error) {
+	factors := map[K]float64{}
+	for name, factor := range table {
+		found := false
+		for key, keyName := range names {
+			if keyName == name {
+				factors[key] = factor
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown key '%s' in calibration table '%s'", name, tableName)
+		}
+	}
+	return factors, nil
+}
+
+// newNeutralMaterialTable returns a material table assigning the same factor to every material.
+func newNeutralMaterialTable(factor float64) map[MATERIAL]float64 {
+	table := map[MATERIAL]float64{}
+	for material := range MATERIAL_NAMES {
+		table[material] = factor
+	}
+	return table
+}
@@ -0,0 +1,59 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCalibration(t *testing.T) {
+	calibration, err := ParseCalibration([]byte("version = \"custom\"\nspeed_factor_influence = 1.5\n[mode_drag_factor]\nplaning = 0.4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calibration.Version != "custom" {
+		t.Errorf("unexpected version '%s'", calibration.Version)
+	}
+	if calibration.SpeedFactorInfluence != 1.5 || calibration.ModeDragFactor[MODE_PLANING] != 0.4 {
+		t.Errorf("expected the specified values, got %v and %v", calibration.SpeedFactorInfluence, calibration.ModeDragFactor[MODE_PLANING])
+	}
+	// unspecified values are taken from the default calibration.
+	if calibration.AgilityPointScale != AGILITY_POINT_SCALE || calibration.ModeDragFactor[MODE_DISPLACE] != MODE_DRAG_FACTOR[MODE_DISPLACE] {
+		t.Errorf("expected the default values, got %v and %v", calibration.AgilityPointScale, calibration.ModeDragFactor[MODE_DISPLACE])
+	}
+}
+
+func TestParseCalibrationErrors(t *testing.T) {
+	tests := []struct {
+		raw     string
+		message string
+	}{
+		{`version = ""`, "calibration version must not be empty"},
+		{"version = \"custom\"\nspeed = 1", "unknown calibration key 'speed'"},
+		{"version = \"custom\"\n[hull_agility_factor]\nraft = 1", "unknown key 'raft' in calibration table 'hull_agility_factor'"},
+	}
+	for _, test := range tests {
+		_, err := ParseCalibration([]byte(test.raw))
+		if err == nil || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("expected error '%s', got %v", test.message, err)
+		}
+	}
+}
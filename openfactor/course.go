@@ -0,0 +1,55 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+// CourseProfile specifies how a course type is composed of upwind, reaching and downwind legs.
+type CourseProfile struct {
+	// Name specifies the course identifier (e.g. 'coastal').
+	Name string
+	// Upwind specifies the proportion of upwind legs.
+	Upwind float64
+	// Reach specifies the proportion of reaching legs.
+	Reach float64
+	// Downwind specifies the proportion of downwind legs.
+	Downwind float64
+}
+
+var COURSE_PROFILES = []CourseProfile{
+	{Name: "windward_leeward", Upwind: 0.5, Reach: 0, Downwind: 0.5},         // sausage courses with beats and runs only.
+	{Name: "coastal", Upwind: 0.2, Reach: 0.6, Downwind: 0.2},                // long distance courses that are mostly reaching.
+	{Name: "random_leg", Upwind: 1.0 / 3, Reach: 1.0 / 3, Downwind: 1.0 / 3}, // courses set around fixed marks with legs in every direction.
+}
+
+// evaluateCourse derives the TCC of the course by weighting the speed points with the course proportions.
+// the drag points are independent of the point of sail and keep their weight.
+func evaluateCourse(c *Calibration, output *EvaluationOutput, course CourseProfile) float64 {
+	proportion := course.Upwind + course.Reach + course.Downwind
+	if proportion <= 0 {
+		return output.TCC
+	}
+	sailPoints := (output.SpeedUpwindPoints*course.Upwind +
+		output.SpeedReachPoints*course.Reach +
+		output.SpeedDownwindPoints*course.Downwind) / proportion
+
+	speedPoints := (output.SpeedDragPoints + sailPoints*2) / 3
+	speedFactor := c.PointAnchor - (speedPoints / POINT_DIVIDOR)
+
+	return evaluateTCC(c, speedFactor, output.StabilizationFactor, output.AgilityFactor) * (1 + output.AgeAllowance)
+}
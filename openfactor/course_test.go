@@ -0,0 +1,57 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import "testing"
+
+func TestEvaluateCourse(t *testing.T) {
+	c := DefaultCalibration()
+	output, err := EvaluateFactor(newTestInput(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		course CourseProfile
+		tcc    float64
+	}{
+		// windward leeward courses weight the speed points as the overall rating.
+		{"windward_leeward", COURSE_PROFILES[0], 0.835},
+		{"coastal", COURSE_PROFILES[1], 0.8323333333333333},
+		{"random_leg", COURSE_PROFILES[2], 0.8335185185185187},
+		// proportions are normalized.
+		{"unnormalized", CourseProfile{Name: "unnormalized", Upwind: 2, Reach: 6, Downwind: 2}, 0.8323333333333333},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertFloat(t, "course tcc", evaluateCourse(c, output, test.course), test.tcc)
+		})
+	}
+
+	if len(output.Courses) != len(COURSE_PROFILES) {
+		t.Fatalf("expected %d courses, got %d", len(COURSE_PROFILES), len(output.Courses))
+	}
+	for i, course := range output.Courses {
+		if course.Name != COURSE_PROFILES[i].Name {
+			t.Errorf("unexpected course %d: expected '%s', got '%s'", i, COURSE_PROFILES[i].Name, course.Name)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import "math"
+
+// empirical crew constants used by the DefaultCalibration.
+// a crew without configuration (zero value) is rated neutral with the full crew weight.
+const (
+	CREW_MEMBER_WEIGHT          = 85   // average weight of a sailor in kg, used to derive the weight of a counted crew
+	SHORT_HANDED_AGILITY_FACTOR = 0.85 // empirical value specifying how much slower maneuvers are sailed with fewer hands
+	SHORT_HANDED_SPEED_FACTOR   = 0.95 // empirical value specifying how much sail power is lost as sails are trimmed and changed less often
+	AUTOPILOT_RECOVERY          = 0.5  // empirical value specifying the share of the short-handed speed loss recovered with an autopilot
+)
+
+// Crew specifies the crew configuration the ship is sailed with.
+type Crew struct {
+	// Count specifies the number of crew members (including the helmsman).
+	// If zero, the crew is rated with the full crew weight.
+	Count int64
+	// ShortHanded specifies whether the ship is sailed short-handed (e.g. single- or double-handed).
+	ShortHanded bool
+	// Autopilot specifies whether an autopilot is allowed.
+	Autopilot bool
+}
+
+// evaluateCrewWeight calcs the effective crew weight, a counted crew can't exceed the maximum crew weight.
+func evaluateCrewWeight(c *Calibration, crewWeight float64, crew Crew) float64 {
+	if crew.Count > 0 {
+		return math.Min(crewWeight, float64(crew.Count)*c.CrewMemberWeight)
+	}
+	return crewWeight
+}
+
+// evaluateCrewSpeedFactor calcs how much of the sail power the crew can convert into speed.
+func evaluateCrewSpeedFactor(c *Calibration, crew Crew) float64 {
+	if !crew.ShortHanded {
+		return 1
+	}
+	if crew.Autopilot {
+		// the autopilot steers while the crew trims, recovering a part of the speed loss.
+		return 1 - (1-c.ShortHandedSpeedFactor)*(1-c.AutopilotRecovery)
+	}
+	return c.ShortHandedSpeedFactor
+}
+
+// evaluateCrewAgilityFactor calcs how well the crew can sail maneuvers.
+func evaluateCrewAgilityFactor(c *Calibration, crew Crew) float64 {
+	if !crew.ShortHanded {
+		return 1
+	}
+	return c.ShortHandedAgilityFactor
+}
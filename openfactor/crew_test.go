@@ -0,0 +1,65 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import "testing"
+
+func TestEvaluateCrewFactors(t *testing.T) {
+	c := DefaultCalibration()
+	tests := []struct {
+		name    string
+		crew    Crew
+		speed   float64
+		agility float64
+	}{
+		{"full_crew", Crew{}, 1, 1},
+		{"short_handed", Crew{Count: 2, ShortHanded: true}, 0.95, 0.85},
+		{"short_handed_autopilot", Crew{Count: 1, ShortHanded: true, Autopilot: true}, 0.975, 0.85},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertFloat(t, "speed factor", evaluateCrewSpeedFactor(c, test.crew), test.speed)
+			assertFloat(t, "agility factor", evaluateCrewAgilityFactor(c, test.crew), test.agility)
+		})
+	}
+}
+
+func TestEvaluateFactorShortHanded(t *testing.T) {
+	tests := []struct {
+		name string
+		crew Crew
+		tcc  float64
+	}{
+		{"full_crew", Crew{}, 0.835},
+		// the crew weight drops to 170 kg and maneuvers are slower, the agility points drop from 76 to 72.
+		{"double_handed", Crew{Count: 2, ShortHanded: true}, 2.525 / 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := newTestInput()
+			input.Crew = test.crew
+			output, err := EvaluateFactor(input, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertFloat(t, "tcc", output.TCC, test.tcc)
+		})
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/megakuul/opensail/openfactor
 
 go 1.23.3
+
+require github.com/BurntSushi/toml v1.4.0
@@ -20,7 +20,9 @@
 package openfactor
 
 import (
+	"maps"
 	"math"
+	"slices"
 )
 
 // Version returns the algorithm version.
@@ -28,6 +30,7 @@ func Version() string {
 	return "v0.0.1"
 }
 
+// empirical constants used by the DefaultCalibration.
 const (
 	POINT_DIVIDOR = 100 // number used to convert points to decimal
 	POINT_ANCHOR  = 2   // anchor used to convert points to factor
@@ -39,8 +42,20 @@ const (
 	DRAG_SPEED_POINT_PATCHER     = 80 // empirical value to patch the drag speed points
 	UPWIND_SPEED_POINT_PATCHER   = 60 // empirical value to patch the upwind speed points
 	DOWNWIND_SPEED_POINT_PATCHER = 60 // empirical value to patch the downwind speed points
+	REACH_SPEED_POINT_PATCHER    = 60 // empirical value to patch the reach speed points
 	STABILIZATION_POINT_PATCHER  = 80 // empirical value to patch the stabilization points
 	AGILITY_POINT_PATCHER        = 70 // empirical value to patch the agility points
+
+	DRAG_IMPACT_SCALE         = 3.5 // empirical value scaling the wsa into the drag impact
+	DRAG_POINT_NORMALIZER     = 15  // empirical value normalizing the drag impact into the drag point scale
+	SAIL_POWER_NORMALIZER     = 10  // empirical value normalizing the sail power into the speed point scale
+	STABILIZATION_POINT_SCALE = 3   // empirical value scaling the stabilization impact into the stabilization point scale
+	AGILITY_POINT_SCALE       = 1.8 // empirical value scaling the agility impact into the agility point scale
+
+	// v0.0.1 specifies the edge length exponents as integer divisions (1/2 and 1/3), which evaluate to 0.
+	// the ratios between sail area, wsa and displacement are therefore always 1. This is kept to reproduce v0.0.1 ratings.
+	AREA_EXPONENT   = 0 // exponent converting an area into an edge length
+	VOLUME_EXPONENT = 0 // exponent converting a volume into an edge length
 )
 
 type MODE int64
@@ -53,6 +68,18 @@ const (
 	MODE_DISPLACE
 )
 
+var MODE_NAMES = map[MODE]string{
+	MODE_DEFAULT:   "default",
+	MODE_HYDROFOIL: "hydrofoil",
+	MODE_PLANING:   "planing",
+	MODE_SEMI:      "semi",
+	MODE_DISPLACE:  "displace",
+}
+
+func (m MODE) String() string {
+	return MODE_NAMES[m]
+}
+
 var MODE_DRAG_FACTOR = map[MODE]float64{
 	MODE_DEFAULT:   0,    // default mode is considered 0 drag - boat does not touch the water.
 	MODE_HYDROFOIL: 0.05, // hydrofoil mode provides optimal drag (nearly 0).
@@ -71,27 +98,53 @@ const (
 	STABILIZATION_CENTREBOARD
 	STABILIZATION_DAGGERBOARD
 	STABILIZATION_FOILS
+	STABILIZATION_LIFTINGKEEL
+	STABILIZATION_SWINGKEEL
+	STABILIZATION_CANTINGKEEL
 )
 
+var STABILIZATION_NAMES = map[STABILIZATION]string{
+	STABILIZATION_DEFAULT:     "default",
+	STABILIZATION_BULBKEEL:    "bulbkeel",
+	STABILIZATION_FINKEEL:     "finkeel",
+	STABILIZATION_FULLKEEL:    "fullkeel",
+	STABILIZATION_CENTREBOARD: "centreboard",
+	STABILIZATION_DAGGERBOARD: "daggerboard",
+	STABILIZATION_FOILS:       "foils",
+	STABILIZATION_LIFTINGKEEL: "liftingkeel",
+	STABILIZATION_SWINGKEEL:   "swingkeel",
+	STABILIZATION_CANTINGKEEL: "cantingkeel",
+}
+
+func (s STABILIZATION) String() string {
+	return STABILIZATION_NAMES[s]
+}
+
 // sorry for this retarded variable name but it fits into scheme...
 var STABILIZATION_STABILIZATION_FACTOR = map[STABILIZATION]float64{
-	STABILIZATION_DEFAULT:     1,   // default has perfect stabilization
-	STABILIZATION_FULLKEEL:    0.9, // fullkeel provides the best stability due to ballast + righting force
-	STABILIZATION_BULBKEEL:    0.8, // bulbkeel provides decent stability mainly due to well distributed ballast
-	STABILIZATION_FINKEEL:     0.7, // finkeel provides decent stability with decent distributed ballast
-	STABILIZATION_CENTREBOARD: 0.2, // centreboard provides some righting force but no distributed ballast
-	STABILIZATION_DAGGERBOARD: 0.2, // daggerboard provides some righting force but no distributed ballast
-	STABILIZATION_FOILS:       0.1, // foils provide decent righting force but are generally harder to operate
+	STABILIZATION_DEFAULT:     1,    // default has perfect stabilization
+	STABILIZATION_FULLKEEL:    0.9,  // fullkeel provides the best stability due to ballast + righting force
+	STABILIZATION_BULBKEEL:    0.8,  // bulbkeel provides decent stability mainly due to well distributed ballast
+	STABILIZATION_FINKEEL:     0.7,  // finkeel provides decent stability with decent distributed ballast
+	STABILIZATION_CENTREBOARD: 0.2,  // centreboard provides some righting force but no distributed ballast
+	STABILIZATION_DAGGERBOARD: 0.2,  // daggerboard provides some righting force but no distributed ballast
+	STABILIZATION_FOILS:       0.1,  // foils provide decent righting force but are generally harder to operate
+	STABILIZATION_LIFTINGKEEL: 0.75, // liftingkeel provides nearly the stability of a bulbkeel, the lifting mechanism limits the ballast depth
+	STABILIZATION_SWINGKEEL:   0.6,  // swingkeel provides decent stability but the pivoting ballast sits higher than on fixed keels
+	STABILIZATION_CANTINGKEEL: 0.95, // cantingkeel moves the ballast to windward and provides the strongest righting force
 }
 
 var STABILIZATION_AGILITY_FACTOR = map[STABILIZATION]float64{
-	STABILIZATION_DEFAULT:     1,   // default has perfect stabilization
-	STABILIZATION_BULBKEEL:    0.9, // bulbkeel provides well agility and enables more risky maneuvers due to distributed ballast
-	STABILIZATION_FINKEEL:     0.8, // finkeel provides well agility and enables more risky maneuvers due to distributed the ballast
-	STABILIZATION_CENTREBOARD: 0.7, // centreboard provides decent agility due to the lack of stabilization
-	STABILIZATION_DAGGERBOARD: 0.7, // daggerboard provides decent agility due to the lack of stabilization
-	STABILIZATION_FULLKEEL:    0.4, // fullkeel provides rather limited agility due to the strong straight righting force
-	STABILIZATION_FOILS:       0.2, // foils provide rather bad agility and are generally harder to operate
+	STABILIZATION_DEFAULT:     1,    // default has perfect stabilization
+	STABILIZATION_BULBKEEL:    0.9,  // bulbkeel provides well agility and enables more risky maneuvers due to distributed ballast
+	STABILIZATION_FINKEEL:     0.8,  // finkeel provides well agility and enables more risky maneuvers due to distributed the ballast
+	STABILIZATION_CENTREBOARD: 0.7,  // centreboard provides decent agility due to the lack of stabilization
+	STABILIZATION_DAGGERBOARD: 0.7,  // daggerboard provides decent agility due to the lack of stabilization
+	STABILIZATION_FULLKEEL:    0.4,  // fullkeel provides rather limited agility due to the strong straight righting force
+	STABILIZATION_FOILS:       0.2,  // foils provide rather bad agility and are generally harder to operate
+	STABILIZATION_LIFTINGKEEL: 0.85, // liftingkeel provides well agility similar to a bulbkeel
+	STABILIZATION_SWINGKEEL:   0.75, // swingkeel provides decent agility, the pivoting keel is less stiff through maneuvers
+	STABILIZATION_CANTINGKEEL: 0.5,  // cantingkeel must be recanted with every maneuver, which limits the agility
 }
 
 type HULL int64
@@ -100,18 +153,40 @@ const (
 	HULL_DEFAULT HULL = iota
 	HULL_MULTI
 	HULL_MONO
+	HULL_CATAMARAN
+	HULL_TRIMARAN
+	HULL_PROA
 )
 
+var HULL_NAMES = map[HULL]string{
+	HULL_DEFAULT:   "default",
+	HULL_MULTI:     "multi",
+	HULL_MONO:      "mono",
+	HULL_CATAMARAN: "catamaran",
+	HULL_TRIMARAN:  "trimaran",
+	HULL_PROA:      "proa",
+}
+
+func (h HULL) String() string {
+	return HULL_NAMES[h]
+}
+
 var HULL_STABILIZATION_FACTOR = map[HULL]float64{
-	HULL_DEFAULT: 1,   // default has perfect stabilization
-	HULL_MULTI:   0.9, // multi hull provides almost optimal stabilization
-	HULL_MONO:    0.7, // mono hull provides decent stabilization
+	HULL_DEFAULT:   1,    // default has perfect stabilization
+	HULL_MULTI:     0.9,  // multi hull provides almost optimal stabilization
+	HULL_MONO:      0.7,  // mono hull provides decent stabilization
+	HULL_CATAMARAN: 0.95, // catamaran provides the widest platform and therefore the most form stability
+	HULL_TRIMARAN:  0.85, // trimaran floats provide form stability, but the main hull carries most of the load
+	HULL_PROA:      0.75, // proa relies on the weight of the single outrigger and can only be sailed with it to windward
 }
 
 var HULL_AGILITY_FACTOR = map[HULL]float64{
-	HULL_DEFAULT: 1,   // default has perfect agility
-	HULL_MONO:    0.9, // mono hull has nearly perfect agility
-	HULL_MULTI:   0.2, // multi hull has limited agility
+	HULL_DEFAULT:   1,    // default has perfect agility
+	HULL_MONO:      0.9,  // mono hull has nearly perfect agility
+	HULL_MULTI:     0.2,  // multi hull has limited agility
+	HULL_CATAMARAN: 0.2,  // catamaran loses most of its speed in tacks as both hulls must be turned
+	HULL_TRIMARAN:  0.35, // trimaran pivots around the main hull and tacks better than a catamaran
+	HULL_PROA:      0.1,  // proa can not tack, it must shunt (reverse bow and stern) to change course
 }
 
 type MATERIAL int64
@@ -127,6 +202,49 @@ const (
 	MATERIAL_AMENITY
 )
 
+var MATERIAL_NAMES = map[MATERIAL]string{
+	MATERIAL_DEFAULT: "default",
+	MATERIAL_BALLAST: "ballast",
+	MATERIAL_CFK:     "cfk",
+	MATERIAL_ALU:     "alu",
+	MATERIAL_GFK:     "gfk",
+	MATERIAL_WOOD:    "wood",
+	MATERIAL_ENGINE:  "engine",
+	MATERIAL_AMENITY: "amenity",
+}
+
+func (m MATERIAL) String() string {
+	return MATERIAL_NAMES[m]
+}
+
+// the material tables weight the points by the material composition (used from v0.0.2 on).
+var MATERIAL_STIFFNESS_FACTOR = map[MATERIAL]float64{
+	MATERIAL_DEFAULT: 1,    // default material gets no bonus
+	MATERIAL_BALLAST: 1,    // ballast does not contribute to the hull stiffness (it is considered in the stabilization)
+	MATERIAL_CFK:     1.15, // cfk provides a very stiff and light structure that transfers the rig power into speed
+	MATERIAL_ALU:     1.05, // alu provides a stiff structure but is heavier than cfk
+	MATERIAL_GFK:     1,    // gfk is the reference material of most production boats
+	MATERIAL_WOOD:    0.95, // wood structures are generally softer and absorb more of the rig power
+	MATERIAL_ENGINE:  1,    // engine does not contribute to the hull stiffness
+	MATERIAL_AMENITY: 1,    // amenities do not contribute to the hull stiffness
+}
+
+var MATERIAL_DISTRIBUTION_FACTOR = map[MATERIAL]float64{
+	MATERIAL_DEFAULT: 1,   // default material gets no bonus
+	MATERIAL_BALLAST: 1,   // ballast distribution is considered in the stabilization
+	MATERIAL_CFK:     1.1, // cfk structures keep the weight low and centered which makes the boat more agile
+	MATERIAL_ALU:     1,   // alu structures are considered neutral
+	MATERIAL_GFK:     1,   // gfk structures are considered neutral
+	MATERIAL_WOOD:    0.9, // wood structures (especially interiors) add weight spread all over the boat
+	MATERIAL_ENGINE:  0.6, // engine is a concentrated weight in the hull that must be moved with every maneuver
+	MATERIAL_AMENITY: 0.7, // amenities add weight in the ends and the top of the boat which hampers agility
+}
+
+var MATERIAL_DRAG_FACTOR = map[MATERIAL]float64{
+	MATERIAL_DEFAULT: 0, // default material does not add drag
+	MATERIAL_ENGINE:  5, // engine adds propeller and shaft (or saildrive) drag, relative to the engine share
+}
+
 // EvaluationInput specifies data that is used to derive the rating.
 type EvaluationInput struct {
 	// LOA specifies the ship length in meters
@@ -154,11 +272,18 @@ type EvaluationInput struct {
 	// SymmetricSpinnakerArea specifies the size of the
 	// largest symmetric downwind sail in square meters.
 	SymmetricSpinnakerArea float64
+	// CodeZeroArea specifies the size of the
+	// code zero (tight reaching sail) in square meters.
+	CodeZeroArea float64
 
 	// Displacement specifies the ship displacement in KG (this is the same as the weight of the ship)
 	Displacement float64
 	// CrewWeight specifies the weight of the crew on the ship.
 	CrewWeight float64
+	// Crew specifies the crew configuration the ship is sailed with.
+	Crew Crew
+	// ConstructionYear specifies the year the ship was built (0 if unknown).
+	ConstructionYear int64
 
 	// Mode specifies the hull operation mode.
 	Mode MODE
@@ -166,12 +291,16 @@ type EvaluationInput struct {
 	Stabilization STABILIZATION
 	// Hull specifies the hull design of the ship.
 	Hull HULL
+	// Rig specifies the rig attributes of the ship.
+	Rig Rig
 
 	// Composition specifies how the ship is composed.
 	Composition map[MATERIAL]float64
 }
 
 type EvaluationOutput struct {
+	// Version specifies the calibration version used to derive the rating.
+	Version string
 	// Time correction coefficient produced by the algorithm.
 	TCC float64
 	// SpeedFactor specifies the factor the boat retrieved in category "Speed".
@@ -180,6 +309,7 @@ type EvaluationOutput struct {
 	SpeedDragPoints     float64
 	SpeedUpwindPoints   float64
 	SpeedDownwindPoints float64
+	SpeedReachPoints    float64
 
 	// StabilizationFactor specifies the factor the boat retrieved in category "Stability".
 	StabilizationFactor    float64
@@ -190,28 +320,141 @@ type EvaluationOutput struct {
 	AgilityFactor    float64
 	AgilityPoints    float64
 	AgilityInfluence float64
+
+	// AgeAllowance specifies the relative TCC credit due to the age of the ship (included in every TCC).
+	AgeAllowance float64
+
+	// SecondsPerMile specifies the time-on-distance allowance in seconds per nautical mile.
+	SecondsPerMile float64
+	// PLT and PLD specify the performance line (time and distance coefficient).
+	PLT float64
+	PLD float64
+
+	// WindBands specifies the TCC of every wind band in the calibration.
+	WindBands []WindBandOutput
+	// Courses specifies the TCC of every course profile in the calibration.
+	Courses []CourseOutput
+
+	// Trace specifies the intermediate terms of every point category.
+	Trace []TraceEntry
+}
+
+type WindBandOutput struct {
+	// Name specifies the wind band identifier.
+	Name string
+	// MinWindSpeed specifies the lower bound of the true wind speed in knots.
+	MinWindSpeed float64
+	// MaxWindSpeed specifies the upper bound of the true wind speed in knots.
+	MaxWindSpeed float64
+	// Time correction coefficient produced by the algorithm in this wind band.
+	TCC float64
+}
+
+type CourseOutput struct {
+	// Name specifies the course identifier.
+	Name string
+	// Upwind, Reach and Downwind specify the course proportions.
+	Upwind   float64
+	Reach    float64
+	Downwind float64
+	// Time correction coefficient produced by the algorithm for this course.
+	TCC float64
 }
 
-func EvaluateFactor(input *EvaluationInput) (*EvaluationOutput, error) {
-	speedDragPoints := math.Round(evaluateDragSpeedPoints(
+// EvaluateFactor derives the rating of the ship with the provided calibration.
+// If no calibration is provided, the DefaultCalibration is used.
+func EvaluateFactor(input *EvaluationInput, calibration *Calibration) (*EvaluationOutput, error) {
+	if calibration == nil {
+		calibration = DefaultCalibration()
+	}
+	if err := ValidateInput(input, calibration); err != nil {
+		return nil, err
+	}
+
+	t := &tracer{}
+	output := evaluateFactor(input, calibration, math.Round, t)
+	output.Trace = t.entries
+	for _, band := range calibration.WindBands {
+		bandOutput := evaluateFactor(input, calibration.windBandCalibration(band), math.Round, nil)
+		output.WindBands = append(output.WindBands, WindBandOutput{
+			Name:         band.Name,
+			MinWindSpeed: band.MinWindSpeed,
+			MaxWindSpeed: band.MaxWindSpeed,
+			TCC:          bandOutput.TCC,
+		})
+	}
+	output.SecondsPerMile = evaluateSecondsPerMile(calibration, output.TCC)
+	output.PLT, output.PLD = evaluatePerformanceLine(calibration, output.TCC, output.WindBands)
+	for _, course := range calibration.Courses {
+		output.Courses = append(output.Courses, CourseOutput{
+			Name:     course.Name,
+			Upwind:   course.Upwind,
+			Reach:    course.Reach,
+			Downwind: course.Downwind,
+			TCC:      evaluateCourse(calibration, output, course),
+		})
+	}
+	if err := validateOutput(output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// evaluateFactor derives the rating of the ship in the wind range covered by the calibration.
+// roundPoints is applied to every point category, published ratings use integer points.
+// The intermediate terms are recorded to the tracer (if provided).
+func evaluateFactor(input *EvaluationInput, calibration *Calibration, roundPoints func(float64) float64, t *tracer) *EvaluationOutput {
+	speedDragPoints := roundPoints(evaluateDragSpeedPoints(
+		calibration,
+		t,
 		input.Mode,
 		input.WSA,
+		input.Composition,
 	))
-	speedUpwindPoints := math.Round(evaluateUpwindSpeedPoints(
+	speedUpwindPoints := roundPoints(evaluateUpwindSpeedPoints(
+		calibration,
+		t,
 		input.Displacement,
 		input.MainSailArea,
 		input.AsymmetricSpinnakerArea,
 		input.IMSL,
+		input.CodeZeroArea,
+		input.Rig,
+		input.Crew,
+		input.Composition,
 	))
-	speedDownwindPoints := math.Round(evaluateDownwindSpeedPoints(
+	speedDownwindPoints := roundPoints(evaluateDownwindSpeedPoints(
+		calibration,
+		t,
 		input.Displacement,
+		input.LOA,
 		input.AsymmetricSpinnakerArea,
 		input.SymmetricSpinnakerArea,
+		input.Rig,
+		input.Crew,
+		input.Composition,
+	))
+	// reach points are not part of the overall speed points, they are only used for course specific ratings.
+	speedReachPoints := roundPoints(evaluateReachSpeedPoints(
+		calibration,
+		t,
+		input.Displacement,
+		input.MainSailArea,
+		input.JibSailArea,
+		input.AsymmetricSpinnakerArea,
+		input.SymmetricSpinnakerArea,
+		input.CodeZeroArea,
+		input.Rig,
+		input.Crew,
+		input.Composition,
 	))
 	speedPoints := (speedDragPoints + speedUpwindPoints + speedDownwindPoints) / 3
-	speedFactor := POINT_ANCHOR - (speedPoints / POINT_DIVIDOR)
+	speedFactor := calibration.PointAnchor - (speedPoints / POINT_DIVIDOR)
 
-	stabilizationPoints := math.Round(evaluateStabilizationPoints(
+	stabilizationPoints := roundPoints(evaluateStabilizationPoints(
+		calibration,
+		t,
 		input.WSA,
 		input.MaxDraft,
 		input.MaxBeam,
@@ -222,87 +465,181 @@ func EvaluateFactor(input *EvaluationInput) (*EvaluationOutput, error) {
 		input.Stabilization,
 		input.Hull,
 	))
-	stabilizationFactor := POINT_ANCHOR - (stabilizationPoints / POINT_DIVIDOR)
+	stabilizationFactor := calibration.PointAnchor - (stabilizationPoints / POINT_DIVIDOR)
 
-	agilityPoints := math.Round(evaluateAgilityPoints(
+	agilityPoints := roundPoints(evaluateAgilityPoints(
+		calibration,
+		t,
 		input.MaxBeam,
 		input.LOA,
 		input.CrewWeight,
+		input.Crew,
 		input.Displacement,
+		input.Composition,
 		input.Stabilization,
 		input.Hull,
 	))
-	agilityFactor := POINT_ANCHOR - (agilityPoints / POINT_DIVIDOR)
+	agilityFactor := calibration.PointAnchor - (agilityPoints / POINT_DIVIDOR)
+
+	ageAllowance := evaluateAgeAllowance(calibration, t, input.ConstructionYear)
 
-	tcc := ((speedFactor * SPEED_FACTOR_INFLUENCE) +
-		(stabilizationFactor * STABILIZATION_FACTOR_INFLUENCE) +
-		(agilityFactor * AGILITY_FACTOR_INFLUENCE)) / 3
+	tcc := evaluateTCC(calibration, speedFactor, stabilizationFactor, agilityFactor) * (1 + ageAllowance)
 
 	return &EvaluationOutput{
+		Version:             calibration.Version,
 		TCC:                 tcc,
 		SpeedFactor:         speedFactor,
-		SpeedInfluence:      SPEED_FACTOR_INFLUENCE,
+		SpeedInfluence:      calibration.SpeedFactorInfluence,
 		SpeedDragPoints:     speedDragPoints,
 		SpeedUpwindPoints:   speedUpwindPoints,
 		SpeedDownwindPoints: speedDownwindPoints,
+		SpeedReachPoints:    speedReachPoints,
 
 		StabilizationFactor:    stabilizationFactor,
 		StabilizationPoints:    stabilizationPoints,
-		StabilizationInfluence: STABILIZATION_FACTOR_INFLUENCE,
+		StabilizationInfluence: calibration.StabilizationFactorInfluence,
 
 		AgilityFactor:    agilityFactor,
 		AgilityPoints:    agilityPoints,
-		AgilityInfluence: AGILITY_FACTOR_INFLUENCE,
-	}, nil
+		AgilityInfluence: calibration.AgilityFactorInfluence,
+
+		AgeAllowance: ageAllowance,
+	}
+}
+
+// evaluateTCC combines the category factors into the time correction coefficient.
+func evaluateTCC(c *Calibration, speedFactor, stabilizationFactor, agilityFactor float64) float64 {
+	return ((speedFactor * c.SpeedFactorInfluence) +
+		(stabilizationFactor * c.StabilizationFactorInfluence) +
+		(agilityFactor * c.AgilityFactorInfluence)) / 3
 }
 
 // evaluateDragSpeedPoints calcs the drag speed. more points == fewer drag == good
-func evaluateDragSpeedPoints(mode MODE, wsa float64) float64 {
-	impact := (math.Sqrt(wsa) * 3.5) * MODE_DRAG_FACTOR[mode]
+func evaluateDragSpeedPoints(c *Calibration, t *tracer, mode MODE, wsa float64, material map[MATERIAL]float64) float64 {
+	// materialDrag is added to take appendages like propellers into account.
+	// it is derived from the share of the materials causing the drag (e.g. engine).
+	materialDrag := 0.0
+	for _, m := range slices.Sorted(maps.Keys(material)) {
+		materialDrag += (material[m] * c.MaterialDragFactor[m]) / 100
+	}
+
+	impact := (math.Sqrt(wsa) * c.DragImpactScale) * c.ModeDragFactor[mode] * (1 + materialDrag)
+
+	t.record(TRACE_SPEED_DRAG, "material_drag", materialDrag)
+	t.record(TRACE_SPEED_DRAG, "mode_drag_factor", c.ModeDragFactor[mode])
+	t.record(TRACE_SPEED_DRAG, "impact", impact)
+
 	// normalize (as more drag == less points) and reverse result into a scale ~1.0-2.0
-	return (2.0 - (impact / 15)) * DRAG_SPEED_POINT_PATCHER
+	return (2.0 - (impact / c.DragPointNormalizer)) * c.DragSpeedPointPatcher
 }
 
 // evaluateDownwindSpeedPoints calcs the downwind speed. more points == faster == good
-func evaluateDownwindSpeedPoints(displ, asym, sym float64) float64 {
+func evaluateDownwindSpeedPoints(c *Calibration, t *tracer, displ, loa, asym, sym float64, rig Rig, crew Crew, material map[MATERIAL]float64) float64 {
 	// asymmetric and symmetric downwindsails are not differentiated, as its considered a "strategic decision".
 	// the largest sail is counted, other smaller sails may be used in the race.
 	sailArea := math.Max(sym, asym)
 	displVol := displ / 1000 // assuming water is 1000 kg / m3
 
 	// ratio between the edge length of sailArea and displVol.
-	sailDisplRatio := math.Pow(math.Pow(sailArea, 1/2)/math.Pow(displVol, 1/3), 2)
+	sailDisplRatio := math.Pow(math.Pow(sailArea, c.AreaExponent)/math.Pow(displVol, c.VolumeExponent), 2)
+
+	// stiffnessFactor is added to take into account how well the hull structure transfers the rig power into speed.
+	stiffnessFactor := evaluateCompositionFactor(material, c.MaterialStiffnessFactor)
+
+	// rigFactor is added to take into account how the rig carries the downwind sails (e.g. masthead, bowsprit).
+	rigFactor := evaluateDownwindRigFactor(c, loa, rig)
+	// crewFactor is added to take into account that short-handed crews trim and change the sails less often.
+	crewFactor := evaluateCrewSpeedFactor(c, crew)
+
+	impact := (sailArea * sailDisplRatio) * stiffnessFactor * rigFactor * crewFactor
+
+	t.record(TRACE_SPEED_DOWNWIND, "sail_area", sailArea)
+	t.record(TRACE_SPEED_DOWNWIND, "displ_vol", displVol)
+	t.record(TRACE_SPEED_DOWNWIND, "sail_displ_ratio", sailDisplRatio)
+	t.record(TRACE_SPEED_DOWNWIND, "stiffness_factor", stiffnessFactor)
+	t.record(TRACE_SPEED_DOWNWIND, "rig_factor", rigFactor)
+	t.record(TRACE_SPEED_DOWNWIND, "crew_factor", crewFactor)
+	t.record(TRACE_SPEED_DOWNWIND, "impact", impact)
 
-	impact := (sailArea * sailDisplRatio)
 	// normalize result into a scale ~1.0-2.0
-	return (1.0 + impact/(impact+10)) * DOWNWIND_SPEED_POINT_PATCHER
+	return (1.0 + impact/(impact+c.SailPowerNormalizer)) * c.DownwindSpeedPointPatcher
+}
+
+// evaluateReachSpeedPoints calcs the reaching speed. more points == faster == good
+func evaluateReachSpeedPoints(c *Calibration, t *tracer, displ, main, jib, asym, sym, codeZero float64, rig Rig, crew Crew, material map[MATERIAL]float64) float64 {
+	// on a reach the main is combined with the largest headsail that can be carried.
+	// asymmetric sails and code zeros are designed for reaching, symmetric spinnakers can only be carried on a broad reach
+	// and are therefore counted with half of their area.
+	sailArea := evaluateMainSailArea(c, main, rig) + math.Max(math.Max(jib, codeZero), math.Max(asym, sym/2))
+	displVol := displ / 1000 // assuming water is 1000 kg / m3
+
+	// ratio between the edge length of sailArea and displVol.
+	sailDisplRatio := math.Pow(math.Pow(sailArea, c.AreaExponent)/math.Pow(displVol, c.VolumeExponent), 2)
+
+	// stiffnessFactor is added to take into account how well the hull structure transfers the rig power into speed.
+	stiffnessFactor := evaluateCompositionFactor(material, c.MaterialStiffnessFactor)
+
+	// crewFactor is added to take into account that short-handed crews trim and change the sails less often.
+	crewFactor := evaluateCrewSpeedFactor(c, crew)
+
+	impact := (sailArea * sailDisplRatio) * stiffnessFactor * crewFactor
+
+	t.record(TRACE_SPEED_REACH, "sail_area", sailArea)
+	t.record(TRACE_SPEED_REACH, "displ_vol", displVol)
+	t.record(TRACE_SPEED_REACH, "sail_displ_ratio", sailDisplRatio)
+	t.record(TRACE_SPEED_REACH, "stiffness_factor", stiffnessFactor)
+	t.record(TRACE_SPEED_REACH, "crew_factor", crewFactor)
+	t.record(TRACE_SPEED_REACH, "impact", impact)
+
+	// normalize result into a scale ~1.0-2.0
+	return (1.0 + impact/(impact+c.SailPowerNormalizer)) * c.ReachSpeedPointPatcher
 }
 
 // evaluateUpwindSpeedPoints calcs the upwind speed. more points == faster == good
-func evaluateUpwindSpeedPoints(displ, main, jib, forestay float64) float64 {
+func evaluateUpwindSpeedPoints(c *Calibration, t *tracer, displ, main, jib, forestay, codeZero float64, rig Rig, crew Crew, material map[MATERIAL]float64) float64 {
 	// higher forestay means the sails can be trimmed to use higher winds which are generally faster due to surface friction.
 	// this is not very influential, so only a small fraction of the jib is added.
 	forestayFactor := forestay / 100
-	sailArea := main + jib + (math.Pow(math.Pow(jib, 1/2)*forestayFactor, 2))
+	sailArea := evaluateMainSailArea(c, main, rig) + jib + (math.Pow(math.Pow(jib, c.AreaExponent)*forestayFactor, 2))
+	// code zeros can be carried in tight angles in light air, so only a small fraction is added.
+	sailArea += codeZero * c.CodeZeroUpwindShare
 	displVol := displ / 1000 // assuming water is 1000 kg / m3
 
 	// ratio between the edge length of sailArea and displVol.
-	sailDisplRatio := math.Pow(math.Pow(sailArea, 1/2)/math.Pow(displVol, 1/3), 2)
+	sailDisplRatio := math.Pow(math.Pow(sailArea, c.AreaExponent)/math.Pow(displVol, c.VolumeExponent), 2)
+
+	// stiffnessFactor is added to take into account how well the hull structure transfers the rig power into speed.
+	stiffnessFactor := evaluateCompositionFactor(material, c.MaterialStiffnessFactor)
+
+	// rigFactor is added to take into account how well the rig keeps the sails in shape (e.g. fractional, spreaders).
+	rigFactor := evaluateUpwindRigFactor(c, rig)
+	// crewFactor is added to take into account that short-handed crews trim and change the sails less often.
+	crewFactor := evaluateCrewSpeedFactor(c, crew)
+
+	impact := (sailArea * sailDisplRatio) * stiffnessFactor * rigFactor * crewFactor
+
+	t.record(TRACE_SPEED_UPWIND, "forestay_factor", forestayFactor)
+	t.record(TRACE_SPEED_UPWIND, "sail_area", sailArea)
+	t.record(TRACE_SPEED_UPWIND, "displ_vol", displVol)
+	t.record(TRACE_SPEED_UPWIND, "sail_displ_ratio", sailDisplRatio)
+	t.record(TRACE_SPEED_UPWIND, "stiffness_factor", stiffnessFactor)
+	t.record(TRACE_SPEED_UPWIND, "rig_factor", rigFactor)
+	t.record(TRACE_SPEED_UPWIND, "crew_factor", crewFactor)
+	t.record(TRACE_SPEED_UPWIND, "impact", impact)
 
-	impact := (sailArea * sailDisplRatio)
 	// normalize result into a scale ~1.0-2.0
-	return (1.0 + impact/(impact+10)) * UPWIND_SPEED_POINT_PATCHER
+	return (1.0 + impact/(impact+c.SailPowerNormalizer)) * c.UpwindSpeedPointPatcher
 }
 
 // evaluateStabilizationPoints calcs the boat stabilization. more points == better stabilization == good
-func evaluateStabilizationPoints(wsa, draft, beam, loa, displ, main float64, material map[MATERIAL]float64, stabilization STABILIZATION, hull HULL) float64 {
+func evaluateStabilizationPoints(c *Calibration, t *tracer, wsa, draft, beam, loa, displ, main float64, material map[MATERIAL]float64, stabilization STABILIZATION, hull HULL) float64 {
 	displVol := displ / 1000 // assuming water is 1000 kg / m3
 	// sailDisplRatio is added to take strong heeling forces into account.
 	// spinnaker and jib sails are generally a more controllable and minor heeling forces and therefore ignored.
-	sailDisplRatio := math.Pow(main, 1/2) / math.Pow(displVol, 1/3)
+	sailDisplRatio := math.Pow(main, c.AreaExponent) / math.Pow(displVol, c.VolumeExponent)
 	// wsaDisplRatio is added to take into account how much relative wsa the boat has.
 	// A huge wsa is required for heavier boats, but for light boats this enhances formstability.
-	wsaDisplRatio := math.Pow(wsa, 1/2) / math.Pow(displVol, 1/3)
+	wsaDisplRatio := math.Pow(wsa, c.AreaExponent) / math.Pow(displVol, c.VolumeExponent)
 	// beamLoaRatio is added to take into account the beam relative to loa.
 	// A wide beam (relative to loa) also provides more formstability to the boat.
 	beamLoaRatio := beam / loa
@@ -317,26 +654,77 @@ func evaluateStabilizationPoints(wsa, draft, beam, loa, displ, main float64, mat
 	if ballastPercentage <= 0 {
 		ballastPercentage = 100 // by default the ballast is 100% of the displacement
 	}
-	ballastFactor := (ballastPercentage * STABILIZATION_STABILIZATION_FACTOR[stabilization]) / 100
+	ballastFactor := (ballastPercentage * c.StabilizationStabilizationFactor[stabilization]) / 100
+
+	impact := basicStabilizationPoints * c.HullStabilizationFactor[hull] * ballastFactor
+
+	t.record(TRACE_STABILIZATION, "displ_vol", displVol)
+	t.record(TRACE_STABILIZATION, "sail_displ_ratio", sailDisplRatio)
+	t.record(TRACE_STABILIZATION, "wsa_displ_ratio", wsaDisplRatio)
+	t.record(TRACE_STABILIZATION, "beam_loa_ratio", beamLoaRatio)
+	t.record(TRACE_STABILIZATION, "basic_stabilization_points", basicStabilizationPoints)
+	t.record(TRACE_STABILIZATION, "ballast_percentage", ballastPercentage)
+	t.record(TRACE_STABILIZATION, "stabilization_factor", c.StabilizationStabilizationFactor[stabilization])
+	t.record(TRACE_STABILIZATION, "ballast_factor", ballastFactor)
+	t.record(TRACE_STABILIZATION, "hull_factor", c.HullStabilizationFactor[hull])
+	t.record(TRACE_STABILIZATION, "impact", impact)
 
-	impact := basicStabilizationPoints * HULL_STABILIZATION_FACTOR[hull] * ballastFactor
 	// normalize result into a scale ~1.0-2.0
-	return (1.0 + impact*3) * STABILIZATION_POINT_PATCHER
+	return (1.0 + impact*c.StabilizationPointScale) * c.StabilizationPointPatcher
 }
 
 // evaluateAgilityPoints calcs the boat agility. more points == better agility == good
-func evaluateAgilityPoints(beam, loa, crew, displ float64, stabilization STABILIZATION, hull HULL) float64 {
+func evaluateAgilityPoints(c *Calibration, t *tracer, beam, loa, crewWeight float64, crew Crew, displ float64, material map[MATERIAL]float64, stabilization STABILIZATION, hull HULL) float64 {
 	// beamLoaDiff is added to take into account the difference between loa and beam.
 	// large difference means the ship is compact (and agile), small difference means it's long and thin which makes it less agile.
-	beamLoaDiff := math.Max(loa, beam) / (math.Abs(loa-beam) + 0.0000001)
+	// the ratio is symmetric, so that square multihulls (beam >= loa) are rated like their long counterparts.
+	// the input validation guarantees that the beam differs from the loa.
+	beamLoaDiff := math.Max(loa, beam) / math.Abs(loa-beam)
 	// crewDisplRatio is added to take into account that more crew weight == more agility.
 	// with more crew you can more effective rebalance the weight of the ship and therefore operate more agile.
-	crewDisplRatio := crew / displ
+	// a counted crew (e.g. short-handed) can't use the full crew weight.
+	crewDisplRatio := evaluateCrewWeight(c, crewWeight, crew) / displ
+	// crewFactor is added to take into account that maneuvers are sailed slower with fewer hands.
+	crewFactor := evaluateCrewAgilityFactor(c, crew)
+
+	// distributionFactor is added to take into account how the weight is distributed.
+	// concentrated weights (e.g. engine, amenities) must be moved with every maneuver.
+	distributionFactor := evaluateCompositionFactor(material, c.MaterialDistributionFactor)
 
-	basicAgility := beamLoaDiff * crewDisplRatio
+	basicAgility := beamLoaDiff * crewDisplRatio * distributionFactor * crewFactor
 
 	// loa is mixed in here because in general longer ships have
-	impact := basicAgility * HULL_AGILITY_FACTOR[hull] * STABILIZATION_AGILITY_FACTOR[stabilization]
+	impact := basicAgility * c.HullAgilityFactor[hull] * c.StabilizationAgilityFactor[stabilization]
+
+	t.record(TRACE_AGILITY, "beam_loa_diff", beamLoaDiff)
+	t.record(TRACE_AGILITY, "crew_displ_ratio", crewDisplRatio)
+	t.record(TRACE_AGILITY, "crew_factor", crewFactor)
+	t.record(TRACE_AGILITY, "distribution_factor", distributionFactor)
+	t.record(TRACE_AGILITY, "basic_agility", basicAgility)
+	t.record(TRACE_AGILITY, "hull_factor", c.HullAgilityFactor[hull])
+	t.record(TRACE_AGILITY, "stabilization_factor", c.StabilizationAgilityFactor[stabilization])
+	t.record(TRACE_AGILITY, "impact", impact)
+
 	// normalize result into a scale ~1.0-2.0
-	return (1.0 + impact*1.8) * AGILITY_POINT_PATCHER
+	return (1.0 + impact*c.AgilityPointScale) * c.AgilityPointPatcher
+}
+
+// evaluateCompositionFactor calcs the average material factor weighted by the material percentage.
+// materials without factor are counted as neutral (1), an empty composition results in a neutral factor.
+func evaluateCompositionFactor(material map[MATERIAL]float64, factors map[MATERIAL]float64) float64 {
+	totalPercentage, totalFactor := 0.0, 0.0
+	// the materials are summed in a fixed order to keep the result deterministic.
+	for _, m := range slices.Sorted(maps.Keys(material)) {
+		percentage := material[m]
+		factor, ok := factors[m]
+		if !ok {
+			factor = 1
+		}
+		totalPercentage += percentage
+		totalFactor += percentage * factor
+	}
+	if totalPercentage <= 0 {
+		return 1
+	}
+	return totalFactor / totalPercentage
 }
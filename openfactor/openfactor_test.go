@@ -0,0 +1,142 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import (
+	"math"
+	"testing"
+)
+
+// TEST_TOLERANCE specifies the tolerance used to compare evaluated floats.
+const TEST_TOLERANCE = 1e-9
+
+// newTestInput returns the evaluation input of the register example ship 'sui_example_gc32'.
+func newTestInput() *EvaluationInput {
+	return &EvaluationInput{
+		LOA:                     10,
+		MaxDraft:                2.1,
+		MaxBeam:                 6,
+		IMSL:                    16.5,
+		WSA:                     8.9,
+		MainSailArea:            60,
+		JibSailArea:             23.5,
+		AsymmetricSpinnakerArea: 90,
+		Displacement:            975,
+		CrewWeight:              437.5,
+		Mode:                    MODE_HYDROFOIL,
+		Stabilization:           STABILIZATION_FOILS,
+		Hull:                    HULL_MULTI,
+		Composition: map[MATERIAL]float64{
+			MATERIAL_DEFAULT: 2,
+			MATERIAL_CFK:     98,
+		},
+	}
+}
+
+// newTestDinghyInput returns the evaluation input of the register example ship 'sui_example_hobie'.
+func newTestDinghyInput() *EvaluationInput {
+	return &EvaluationInput{
+		LOA:                    5.51,
+		MaxDraft:               0.71,
+		MaxBeam:                2.6,
+		IMSL:                   8.5,
+		WSA:                    3,
+		MainSailArea:           17,
+		JibSailArea:            4.15,
+		SymmetricSpinnakerArea: 21,
+		Displacement:           180,
+		CrewWeight:             240,
+		Mode:                   MODE_SEMI,
+		Stabilization:          STABILIZATION_DAGGERBOARD,
+		Hull:                   HULL_MULTI,
+		Composition: map[MATERIAL]float64{
+			MATERIAL_DEFAULT: 5,
+			MATERIAL_ALU:     10,
+			MATERIAL_GFK:     85,
+		},
+	}
+}
+
+// assertFloat fails the test if the value differs from the expected value by more than the TEST_TOLERANCE.
+func assertFloat(t *testing.T, name string, value, expected float64) {
+	t.Helper()
+	if math.Abs(value-expected) > TEST_TOLERANCE {
+		t.Errorf("unexpected %s: expected %v, got %v", name, expected, value)
+	}
+}
+
+func TestEvaluateFactor(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  *EvaluationInput
+		tcc    float64
+		points [5]float64 // drag, upwind, downwind, stabilization, agility
+	}{
+		{"gc32", newTestInput(), 0.835, [5]float64{157, 116, 114, 107, 76}},
+		{"hobie", newTestDinghyInput(), 0.9116666666666665, [5]float64{134, 98, 101, 94, 115}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, err := EvaluateFactor(test.input, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertFloat(t, "tcc", output.TCC, test.tcc)
+			points := [5]float64{
+				output.SpeedDragPoints, output.SpeedUpwindPoints, output.SpeedDownwindPoints,
+				output.StabilizationPoints, output.AgilityPoints,
+			}
+			if points != test.points {
+				t.Errorf("unexpected points: expected %v, got %v", test.points, points)
+			}
+		})
+	}
+}
+
+func TestEvaluateCompositionFactor(t *testing.T) {
+	tests := []struct {
+		name     string
+		material map[MATERIAL]float64
+		factor   float64
+	}{
+		{"empty", nil, 1},
+		{"zero", map[MATERIAL]float64{MATERIAL_CFK: 0}, 1},
+		{"single", map[MATERIAL]float64{MATERIAL_CFK: 100}, 1.1},
+		{"weighted", map[MATERIAL]float64{MATERIAL_CFK: 50, MATERIAL_ENGINE: 50}, 0.85},
+		{"unknown_factor", map[MATERIAL]float64{MATERIAL(99): 100}, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertFloat(t, "composition factor", evaluateCompositionFactor(test.material, MATERIAL_DISTRIBUTION_FACTOR), test.factor)
+		})
+	}
+}
+
+func TestEvaluateDragSpeedPointsMaterialDrag(t *testing.T) {
+	material := map[MATERIAL]float64{MATERIAL_GFK: 90, MATERIAL_ENGINE: 10}
+
+	c := DefaultCalibration()
+	assertFloat(t, "neutral drag points", evaluateDragSpeedPoints(c, nil, MODE_DISPLACE, 16, material), evaluateDragSpeedPoints(c, nil, MODE_DISPLACE, 16, nil))
+
+	c.MaterialDragFactor = MATERIAL_DRAG_FACTOR
+	if evaluateDragSpeedPoints(c, nil, MODE_DISPLACE, 16, material) >= evaluateDragSpeedPoints(c, nil, MODE_DISPLACE, 16, nil) {
+		t.Error("expected the engine share to reduce the drag points")
+	}
+}
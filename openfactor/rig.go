@@ -0,0 +1,91 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+// empirical rig constants used by the DefaultCalibration.
+// a rig without any of the attributes (zero value) is rated neutral.
+const (
+	BOWSPRIT_DOWNWIND_FACTOR = 0.5  // empirical value specifying how much the bowsprit length (relative to loa) improves the downwind speed
+	CODE_ZERO_UPWIND_SHARE   = 0.1  // empirical value specifying the share of the code zero that is usable in tight angles
+	SPREADER_UPWIND_FACTOR   = 0.01 // empirical value specifying how much each spreader set improves the upwind speed
+	FURLING_MAIN_FACTOR      = 0.85 // empirical value specifying the effective share of a furling main (no roach, flat profile)
+)
+
+type RIG int64
+
+const (
+	RIG_DEFAULT RIG = iota
+	RIG_FRACTIONAL
+	RIG_MASTHEAD
+)
+
+var RIG_NAMES = map[RIG]string{
+	RIG_DEFAULT:    "default",
+	RIG_FRACTIONAL: "fractional",
+	RIG_MASTHEAD:   "masthead",
+}
+
+func (r RIG) String() string {
+	return RIG_NAMES[r]
+}
+
+var RIG_UPWIND_FACTOR = map[RIG]float64{
+	RIG_DEFAULT:    1,    // default rig is considered neutral
+	RIG_FRACTIONAL: 1.03, // fractional rigs can bend the mast to trim the main and depower in gusts
+	RIG_MASTHEAD:   1,    // masthead rigs rely on large overlapping headsails that are less efficient upwind
+}
+
+var RIG_DOWNWIND_FACTOR = map[RIG]float64{
+	RIG_DEFAULT:    1,    // default rig is considered neutral
+	RIG_FRACTIONAL: 1,    // fractional rigs fly the downwind sails from below the masthead
+	RIG_MASTHEAD:   1.03, // masthead rigs fly the downwind sails from the top of the mast in cleaner air
+}
+
+// Rig specifies the rig attributes of the ship.
+type Rig struct {
+	// Type specifies the rig type.
+	Type RIG
+	// BowspritLength specifies the length of the bowsprit (from the bow) in meters.
+	BowspritLength float64
+	// Spreaders specifies the number of spreader sets.
+	Spreaders int64
+	// FurlingMain specifies whether the mainsail is a (in-mast or in-boom) furling main.
+	FurlingMain bool
+}
+
+// evaluateMainSailArea calcs the effective mainsail area, furling mains lose the roach and are counted with a reduced area.
+func evaluateMainSailArea(c *Calibration, main float64, rig Rig) float64 {
+	if rig.FurlingMain {
+		return main * c.FurlingMainFactor
+	}
+	return main
+}
+
+// evaluateUpwindRigFactor calcs how well the rig converts the sail area into upwind speed.
+func evaluateUpwindRigFactor(c *Calibration, rig Rig) float64 {
+	// more spreaders provide a stiffer mast, which keeps the sails in shape when sailing upwind.
+	return c.RigUpwindFactor[rig.Type] * (1 + float64(rig.Spreaders)*c.SpreaderUpwindFactor)
+}
+
+// evaluateDownwindRigFactor calcs how well the rig converts the sail area into downwind speed.
+func evaluateDownwindRigFactor(c *Calibration, loa float64, rig Rig) float64 {
+	// a bowsprit moves the downwind sails out of the disturbed air of the main.
+	return c.RigDownwindFactor[rig.Type] * (1 + (rig.BowspritLength/loa)*c.BowspritDownwindFactor)
+}
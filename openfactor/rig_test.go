@@ -0,0 +1,61 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import "testing"
+
+func TestEvaluateMainSailArea(t *testing.T) {
+	c := DefaultCalibration()
+	tests := []struct {
+		name string
+		rig  Rig
+		area float64
+	}{
+		{"default", Rig{}, 60},
+		{"furling_main", Rig{FurlingMain: true}, 51},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertFloat(t, "main sail area", evaluateMainSailArea(c, 60, test.rig), test.area)
+		})
+	}
+}
+
+func TestEvaluateRigFactors(t *testing.T) {
+	c := DefaultCalibration()
+	tests := []struct {
+		name     string
+		rig      Rig
+		upwind   float64
+		downwind float64
+	}{
+		{"default", Rig{}, 1, 1},
+		{"fractional", Rig{Type: RIG_FRACTIONAL}, 1.03, 1},
+		{"spreaders", Rig{Spreaders: 2}, 1.02, 1},
+		{"bowsprit", Rig{BowspritLength: 2}, 1, 1.1},
+		{"masthead_bowsprit", Rig{Type: RIG_MASTHEAD, BowspritLength: 1}, 1, 1.03 * 1.05},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertFloat(t, "upwind rig factor", evaluateUpwindRigFactor(c, test.rig), test.upwind)
+			assertFloat(t, "downwind rig factor", evaluateDownwindRigFactor(c, 10, test.rig), test.downwind)
+		})
+	}
+}
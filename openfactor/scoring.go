@@ -0,0 +1,80 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import "math"
+
+// scoring constants used by the DefaultCalibration.
+const (
+	REFERENCE_SECONDS_PER_MILE = 600 // seconds per nautical mile of a ship with TCC 1 (6 knots, matches the scale of the orc GPH)
+	REFERENCE_WIND_SPEED       = 12  // true wind speed in knots in which the reference ship sails REFERENCE_SECONDS_PER_MILE
+)
+
+// evaluateSecondsPerMile calcs the time-on-distance allowance of the ship.
+// Corrected times are derived with: elapsed time - (seconds per mile * distance).
+func evaluateSecondsPerMile(c *Calibration, tcc float64) float64 {
+	// corrected times (time-on-time) are derived by dividing the elapsed time through the TCC,
+	// therefore the expected time of the ship on a mile is proportional to the TCC.
+	return tcc * c.ReferenceSecondsPerMile
+}
+
+// evaluateReferenceSecondsPerMile calcs the seconds per nautical mile the reference ship sails in the wind speed.
+// The speed of the reference ship is assumed to grow with the square root of the wind speed.
+func evaluateReferenceSecondsPerMile(c *Calibration, windSpeed float64) float64 {
+	return c.ReferenceSecondsPerMile * math.Sqrt(c.ReferenceWindSpeed/windSpeed)
+}
+
+// evaluatePerformanceLine calcs the performance line (PLT and PLD) of the ship.
+// Corrected times are derived with: PLT * elapsed time - PLD * distance.
+//
+// The line is fitted (least squares) through the allowances of the wind bands, so that the ship
+// sailing its allowance in any wind band ends up with the corrected time of the reference ship.
+// If the wind bands don't provide a line (e.g. less than two bands), the ship is scored time-on-time.
+func evaluatePerformanceLine(c *Calibration, tcc float64, bands []WindBandOutput) (float64, float64) {
+	shipAllowances, referenceAllowances := []float64{}, []float64{}
+	for _, band := range bands {
+		windSpeed := (band.MinWindSpeed + band.MaxWindSpeed) / 2
+		if windSpeed <= 0 {
+			continue
+		}
+		referenceAllowance := evaluateReferenceSecondsPerMile(c, windSpeed)
+		referenceAllowances = append(referenceAllowances, referenceAllowance)
+		shipAllowances = append(shipAllowances, band.TCC*referenceAllowance)
+	}
+
+	n := float64(len(shipAllowances))
+	meanShip, meanReference := 0.0, 0.0
+	for i := range shipAllowances {
+		meanShip += shipAllowances[i] / n
+		meanReference += referenceAllowances[i] / n
+	}
+	covariance, variance := 0.0, 0.0
+	for i := range shipAllowances {
+		covariance += (shipAllowances[i] - meanShip) * (referenceAllowances[i] - meanReference)
+		variance += (shipAllowances[i] - meanShip) * (shipAllowances[i] - meanShip)
+	}
+	if len(shipAllowances) < 2 || variance == 0 {
+		return 1 / tcc, 0
+	}
+
+	plt := covariance / variance
+	pld := plt*meanShip - meanReference
+	return plt, pld
+}
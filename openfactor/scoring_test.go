@@ -0,0 +1,79 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEvaluateSecondsPerMile(t *testing.T) {
+	c := DefaultCalibration()
+	tests := []struct {
+		tcc            float64
+		secondsPerMile float64
+	}{
+		{1, 600},
+		{0.835, 501},
+		{1.2, 720},
+	}
+	for _, test := range tests {
+		assertFloat(t, "seconds per mile", evaluateSecondsPerMile(c, test.tcc), test.secondsPerMile)
+	}
+}
+
+func TestEvaluatePerformanceLine(t *testing.T) {
+	c := DefaultCalibration()
+	bands := func(tccs ...float64) []WindBandOutput {
+		outputs := []WindBandOutput{}
+		for i, band := range WIND_BANDS {
+			outputs = append(outputs, WindBandOutput{
+				Name:         band.Name,
+				MinWindSpeed: band.MinWindSpeed,
+				MaxWindSpeed: band.MaxWindSpeed,
+				TCC:          tccs[i],
+			})
+		}
+		return outputs
+	}
+
+	tests := []struct {
+		name  string
+		tcc   float64
+		bands []WindBandOutput
+		plt   float64
+		pld   float64
+	}{
+		// without wind bands the ship is scored time-on-time.
+		{"no_bands", 0.8, nil, 1.25, 0},
+		// a constant TCC results in a line through the origin.
+		{"constant_bands", 0.8, bands(0.8, 0.8, 0.8), 1.25, 0},
+		{"gc32", 0.835, bands(0.8752777777777778, 0.8661111111111112, 0.9899999999999999), 1.220621418206058, 65.23554274905905},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plt, pld := evaluatePerformanceLine(c, test.tcc, test.bands)
+			assertFloat(t, "plt", plt, test.plt)
+			if math.Abs(pld-test.pld) > 1e-6 {
+				t.Errorf("unexpected pld: expected %v, got %v", test.pld, pld)
+			}
+		})
+	}
+}
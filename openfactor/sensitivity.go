@@ -0,0 +1,186 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import (
+	"maps"
+	"math"
+)
+
+const (
+	SENSITIVITY_RELATIVE_STEP = 0.001 // relative step used to derive the partial derivatives
+	SENSITIVITY_MINIMUM_STEP  = 0.001 // minimum absolute step used for inputs close to zero
+)
+
+// Sensitivity specifies how the TCC responds to a change of a numeric input.
+type Sensitivity struct {
+	// Input specifies the name of the input (e.g. 'displacement' or 'composition.cfk').
+	Input string
+	// Unit specifies the unit of the input (e.g. 'kg').
+	Unit string
+	// Value specifies the current value of the input.
+	Value float64
+	// Derivative specifies the TCC change per unit of the input.
+	Derivative float64
+	// Elasticity specifies the relative TCC change per relative change of the input
+	// (e.g. 0.1 means that 1% more of the input results in a 0.1% higher TCC).
+	Elasticity float64
+	// NotApplicable specifies that the TCC has no derivative for the input at its current value,
+	// in this case Derivative and Elasticity are zero.
+	NotApplicable bool
+}
+
+// sensitivityInput specifies a numeric input that is analyzed by EvaluateSensitivity.
+type sensitivityInput struct {
+	name  string
+	unit  string
+	value func(input *EvaluationInput) float64
+	apply func(input *EvaluationInput, delta float64)
+	// applicable reports whether the input can be perturbed at its current value (nil means always).
+	applicable func(input *EvaluationInput) bool
+}
+
+var sensitivityInputs = []sensitivityInput{
+	newSensitivityInput("loa", "m", func(i *EvaluationInput) *float64 { return &i.LOA }),
+	newSensitivityInput("max_draft", "m", func(i *EvaluationInput) *float64 { return &i.MaxDraft }),
+	newSensitivityInput("max_beam", "m", func(i *EvaluationInput) *float64 { return &i.MaxBeam }),
+	newSensitivityInput("imsl", "m", func(i *EvaluationInput) *float64 { return &i.IMSL }),
+	newSensitivityInput("wsa", "m2", func(i *EvaluationInput) *float64 { return &i.WSA }),
+	newSensitivityInput("main_sail_area", "m2", func(i *EvaluationInput) *float64 { return &i.MainSailArea }),
+	newSensitivityInput("jib_sail_area", "m2", func(i *EvaluationInput) *float64 { return &i.JibSailArea }),
+	newSensitivityInput("asymmetric_spinnaker_area", "m2", func(i *EvaluationInput) *float64 { return &i.AsymmetricSpinnakerArea }),
+	newSensitivityInput("symmetric_spinnaker_area", "m2", func(i *EvaluationInput) *float64 { return &i.SymmetricSpinnakerArea }),
+	newSensitivityInput("code_zero_area", "m2", func(i *EvaluationInput) *float64 { return &i.CodeZeroArea }),
+	newSensitivityInput("rig.bowsprit_length", "m", func(i *EvaluationInput) *float64 { return &i.Rig.BowspritLength }),
+	newSensitivityInput("displacement", "kg", func(i *EvaluationInput) *float64 { return &i.Displacement }),
+	newSensitivityInput("crew_weight", "kg", func(i *EvaluationInput) *float64 { return &i.CrewWeight }),
+	newBallastSensitivityInput(),
+	newCompositionSensitivityInput(MATERIAL_CFK),
+	newCompositionSensitivityInput(MATERIAL_ALU),
+	newCompositionSensitivityInput(MATERIAL_GFK),
+	newCompositionSensitivityInput(MATERIAL_WOOD),
+	newCompositionSensitivityInput(MATERIAL_ENGINE),
+	newCompositionSensitivityInput(MATERIAL_AMENITY),
+}
+
+// newSensitivityInput creates a sensitivity input for a plain numeric field of the evaluation input.
+func newSensitivityInput(name, unit string, field func(input *EvaluationInput) *float64) sensitivityInput {
+	return sensitivityInput{
+		name: name,
+		unit: unit,
+		value: func(input *EvaluationInput) float64 {
+			return *field(input)
+		},
+		apply: func(input *EvaluationInput, delta float64) {
+			*field(input) += delta
+		},
+	}
+}
+
+// newCompositionSensitivityInput creates a sensitivity input for a material share of the composition.
+// the share is moved from (or to) the default material, so that the composition keeps its total.
+func newCompositionSensitivityInput(material MATERIAL) sensitivityInput {
+	return sensitivityInput{
+		name: "composition." + material.String(),
+		unit: "%",
+		value: func(input *EvaluationInput) float64 {
+			return input.Composition[material]
+		},
+		apply: func(input *EvaluationInput, delta float64) {
+			input.Composition[material] += delta
+			input.Composition[MATERIAL_DEFAULT] -= delta
+		},
+	}
+}
+
+// newBallastSensitivityInput creates the sensitivity input for the ballast share of the composition.
+// without ballast share the displacement is evaluated as ballast entirely (see evaluateStabilizationPoints),
+// this jump makes the ballast share not applicable until the composition specifies a ballast share.
+func newBallastSensitivityInput() sensitivityInput {
+	sensitivity := newCompositionSensitivityInput(MATERIAL_BALLAST)
+	sensitivity.applicable = func(input *EvaluationInput) bool {
+		return input.Composition[MATERIAL_BALLAST] > 0
+	}
+	return sensitivity
+}
+
+// EvaluateSensitivity derives the sensitivity of the TCC to every numeric input.
+// The derivatives are evaluated on unrounded points, as the integer points of the
+// published rating do not respond to small input changes.
+// If no calibration is provided, the DefaultCalibration is used.
+func EvaluateSensitivity(input *EvaluationInput, calibration *Calibration) ([]Sensitivity, error) {
+	if calibration == nil {
+		calibration = DefaultCalibration()
+	}
+	if err := ValidateInput(input, calibration); err != nil {
+		return nil, err
+	}
+
+	deltaTCC := func(delta float64, sensitivity sensitivityInput) float64 {
+		deltaInput := *input
+		deltaInput.Composition = maps.Clone(input.Composition)
+		if deltaInput.Composition == nil {
+			deltaInput.Composition = map[MATERIAL]float64{}
+		}
+		sensitivity.apply(&deltaInput, delta)
+		return evaluateFactor(&deltaInput, calibration, func(points float64) float64 {
+			return points
+		}, nil).TCC
+	}
+
+	sensitivities := []Sensitivity{}
+	for _, sensitivity := range sensitivityInputs {
+		value := sensitivity.value(input)
+		if sensitivity.applicable != nil && !sensitivity.applicable(input) {
+			sensitivities = append(sensitivities, Sensitivity{
+				Input:         sensitivity.name,
+				Unit:          sensitivity.unit,
+				Value:         value,
+				NotApplicable: true,
+			})
+			continue
+		}
+		tcc := deltaTCC(0, sensitivity)
+		step := math.Max(math.Abs(value)*SENSITIVITY_RELATIVE_STEP, SENSITIVITY_MINIMUM_STEP)
+
+		var derivative float64
+		if value-step < 0 {
+			// inputs can't be negative, therefore a forward difference is used close to zero.
+			derivative = (deltaTCC(step, sensitivity) - tcc) / step
+		} else {
+			derivative = (deltaTCC(step, sensitivity) - deltaTCC(-step, sensitivity)) / (2 * step)
+		}
+
+		elasticity := 0.0
+		if tcc != 0 {
+			elasticity = derivative * value / tcc
+		}
+
+		sensitivities = append(sensitivities, Sensitivity{
+			Input:      sensitivity.name,
+			Unit:       sensitivity.unit,
+			Value:      value,
+			Derivative: derivative,
+			Elasticity: elasticity,
+		})
+	}
+
+	return sensitivities, nil
+}
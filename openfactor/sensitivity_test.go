@@ -0,0 +1,106 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEvaluateSensitivity(t *testing.T) {
+	input := newTestInput()
+	sensitivities, err := EvaluateSensitivity(input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sensitivities) != len(sensitivityInputs) {
+		t.Fatalf("expected %d sensitivities, got %d", len(sensitivityInputs), len(sensitivities))
+	}
+	tcc := evaluateFactor(input, DefaultCalibration(), func(points float64) float64 { return points }, nil).TCC
+
+	tests := []struct {
+		input      string
+		unit       string
+		value      float64
+		derivative float64
+	}{
+		// the crew weight only affects the agility: tcc = (... + (2 - (1 + impact*1.8)*70/100)*0.5)/3.
+		{"crew_weight", "kg", 437.5, -(0.5 / 3) * (1.8 * 70 / 100) * (2.5 / 975) * 0.2 * 0.2},
+		// with v0.0.1 the jib is not rated (the upwind points use the asymmetric spinnaker).
+		{"jib_sail_area", "m2", 23.5, 0},
+		// the default calibration does not weight the points by the composition.
+		{"composition.cfk", "%", 98, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			sensitivity := findSensitivity(t, sensitivities, test.input)
+			if sensitivity.Unit != test.unit {
+				t.Errorf("unexpected unit '%s'", sensitivity.Unit)
+			}
+			assertFloat(t, "value", sensitivity.Value, test.value)
+			if math.Abs(sensitivity.Derivative-test.derivative) > math.Abs(test.derivative)*1e-3+TEST_TOLERANCE {
+				t.Errorf("unexpected derivative: expected %v, got %v", test.derivative, sensitivity.Derivative)
+			}
+			assertFloat(t, "elasticity", sensitivity.Elasticity, sensitivity.Derivative*test.value/tcc)
+		})
+	}
+}
+
+func TestEvaluateSensitivityBallast(t *testing.T) {
+	// without ballast share the displacement is evaluated as ballast, the share has no derivative there.
+	input := newTestInput()
+	sensitivities, err := EvaluateSensitivity(input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sensitivity := findSensitivity(t, sensitivities, "composition.ballast")
+	if !sensitivity.NotApplicable || sensitivity.Derivative != 0 || sensitivity.Elasticity != 0 {
+		t.Errorf("expected the ballast share to be not applicable, got %+v", *sensitivity)
+	}
+
+	input.Composition[MATERIAL_BALLAST] = 30
+	input.Composition[MATERIAL_CFK] = 68
+	sensitivities, err = EvaluateSensitivity(input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sensitivity = findSensitivity(t, sensitivities, "composition.ballast")
+	// the tcc is linear in the ballast share, therefore the difference of one percent matches the derivative.
+	baseTCC := evaluateFactor(input, DefaultCalibration(), func(points float64) float64 { return points }, nil).TCC
+	input.Composition[MATERIAL_BALLAST] = 31
+	input.Composition[MATERIAL_DEFAULT] = 1
+	ballastTCC := evaluateFactor(input, DefaultCalibration(), func(points float64) float64 { return points }, nil).TCC
+	if sensitivity.NotApplicable {
+		t.Fatal("expected the ballast share to be applicable")
+	}
+	assertFloat(t, "ballast derivative", sensitivity.Derivative, ballastTCC-baseTCC)
+}
+
+// findSensitivity returns the sensitivity of the specified input.
+func findSensitivity(t *testing.T, sensitivities []Sensitivity, input string) *Sensitivity {
+	t.Helper()
+	for i := range sensitivities {
+		if sensitivities[i].Input == input {
+			return &sensitivities[i]
+		}
+	}
+	t.Fatalf("missing sensitivity of input '%s'", input)
+	return nil
+}
@@ -0,0 +1,56 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+// TRACE_CATEGORY specifies the point category an intermediate term belongs to.
+type TRACE_CATEGORY string
+
+const (
+	TRACE_SPEED_DRAG     TRACE_CATEGORY = "speed_drag"
+	TRACE_SPEED_UPWIND   TRACE_CATEGORY = "speed_upwind"
+	TRACE_SPEED_DOWNWIND TRACE_CATEGORY = "speed_downwind"
+	TRACE_SPEED_REACH    TRACE_CATEGORY = "speed_reach"
+	TRACE_STABILIZATION  TRACE_CATEGORY = "stabilization"
+	TRACE_AGILITY        TRACE_CATEGORY = "agility"
+	TRACE_ALLOWANCE      TRACE_CATEGORY = "allowance"
+)
+
+// TraceEntry specifies an intermediate term of the evaluation.
+type TraceEntry struct {
+	// Category specifies the point category the term belongs to.
+	Category TRACE_CATEGORY
+	// Term specifies the name of the intermediate variable (e.g. 'beam_loa_ratio').
+	Term string
+	// Value specifies the value of the term.
+	Value float64
+}
+
+// tracer records the intermediate terms of an evaluation.
+// A nil tracer discards the terms, this is used for internal evaluations that are not published.
+type tracer struct {
+	entries []TraceEntry
+}
+
+// record adds the term to the trace.
+func (t *tracer) record(category TRACE_CATEGORY, term string, value float64) {
+	if t != nil {
+		t.entries = append(t.entries, TraceEntry{Category: category, Term: term, Value: value})
+	}
+}
@@ -0,0 +1,81 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import "testing"
+
+// traceValue returns the value of the traced term, the test fails if the term is not traced exactly once.
+func traceValue(t *testing.T, entries []TraceEntry, category TRACE_CATEGORY, term string) float64 {
+	t.Helper()
+	values := []float64{}
+	for _, entry := range entries {
+		if entry.Category == category && entry.Term == term {
+			values = append(values, entry.Value)
+		}
+	}
+	if len(values) != 1 {
+		t.Fatalf("expected term '%s.%s' to be traced once, got %d entries", category, term, len(values))
+	}
+	return values[0]
+}
+
+func TestTracerRecord(t *testing.T) {
+	var discarding *tracer
+	discarding.record(TRACE_AGILITY, "impact", 1)
+
+	recording := &tracer{}
+	recording.record(TRACE_AGILITY, "impact", 1)
+	expected := []TraceEntry{
+		{Category: TRACE_AGILITY, Term: "impact", Value: 1},
+	}
+	if len(recording.entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %v", len(expected), recording.entries)
+	}
+	for i := range expected {
+		if recording.entries[i] != expected[i] {
+			t.Errorf("unexpected entry %d: expected %+v, got %+v", i, expected[i], recording.entries[i])
+		}
+	}
+}
+
+func TestEvaluateFactorTrace(t *testing.T) {
+	output, err := EvaluateFactor(newTestInput(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		category TRACE_CATEGORY
+		term     string
+		value    float64
+	}{
+		{TRACE_SPEED_DRAG, "mode_drag_factor", 0.05},
+		{TRACE_SPEED_DOWNWIND, "sail_area", 90},
+		{TRACE_SPEED_DOWNWIND, "displ_vol", 0.975},
+		{TRACE_SPEED_UPWIND, "forestay_factor", 0.165},
+		{TRACE_STABILIZATION, "beam_loa_ratio", 0.6},
+		{TRACE_AGILITY, "beam_loa_diff", 2.5},
+		{TRACE_AGILITY, "crew_displ_ratio", 437.5 / 975},
+	}
+	for _, test := range tests {
+		t.Run(string(test.category)+"."+test.term, func(t *testing.T) {
+			assertFloat(t, test.term, traceValue(t, output.Trace, test.category, test.term), test.value)
+		})
+	}
+}
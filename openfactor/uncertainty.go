@@ -0,0 +1,126 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+)
+
+const (
+	UNCERTAINTY_LOWER_PERCENTILE       = 5   // percentile of the lower TCC bound
+	UNCERTAINTY_UPPER_PERCENTILE       = 95  // percentile of the upper TCC bound
+	UNCERTAINTY_MAXIMUM_REJECTED_SHARE = 0.1 // bounds are refused if more samples are implausible, they would no longer cover the tolerances
+)
+
+// Uncertainty specifies the TCC distribution resulting from the input tolerances.
+type Uncertainty struct {
+	// Samples specifies the number of evaluated samples.
+	Samples int
+	// Rejected specifies the number of discarded samples with implausible inputs (e.g. a negative draft).
+	Rejected int
+	// Median specifies the median TCC of the samples.
+	Median float64
+	// Lower and Upper specify the TCC at the UNCERTAINTY_LOWER_PERCENTILE and UNCERTAINTY_UPPER_PERCENTILE.
+	Lower float64
+	Upper float64
+}
+
+// EvaluateUncertainty derives the TCC distribution by sampling the inputs inside of their tolerances.
+// Tolerances are relative (e.g. 0.1 for ±10%) and keyed by the input name (e.g. 'displacement' or 'composition.cfk'),
+// they are treated as the 95% range of a normal distribution around the specified value.
+// The sampling is deterministic for a seed.
+//
+// The samples are evaluated on unrounded points, the integer points of the published rating would quantize the bounds.
+// The evaluation fails if more than UNCERTAINTY_MAXIMUM_REJECTED_SHARE of the samples are implausible.
+func EvaluateUncertainty(algorithm Algorithm, input *EvaluationInput, tolerances map[string]float64, samples int, seed int64) (*Uncertainty, error) {
+	if samples < 1 {
+		return nil, fmt.Errorf("invalid number of uncertainty samples '%d'", samples)
+	}
+
+	if err := ValidateTolerances(tolerances); err != nil {
+		return nil, err
+	}
+
+	random := rand.New(rand.NewSource(seed))
+	tccs := []float64{}
+	rejected := 0
+	for i := 0; i < samples; i++ {
+		sampleInput := *input
+		sampleInput.Composition = maps.Clone(input.Composition)
+		if sampleInput.Composition == nil {
+			sampleInput.Composition = map[MATERIAL]float64{}
+		}
+		// the inputs are sampled in the fixed order of the sensitivity inputs, so that the seed results in the same samples.
+		for _, sensitivity := range sensitivityInputs {
+			tolerance, ok := tolerances[sensitivity.name]
+			if !ok {
+				continue
+			}
+			// the tolerance covers ~2 standard deviations.
+			deviation := random.NormFloat64() * sensitivity.value(input) * tolerance / 2
+			sensitivity.apply(&sampleInput, deviation)
+		}
+
+		sampleOutput, err := algorithm.EvaluateUnrounded(&sampleInput)
+		if err != nil {
+			rejected++
+			continue
+		}
+		tccs = append(tccs, sampleOutput.TCC)
+	}
+	if len(tccs) < 1 || float64(rejected) > float64(samples)*UNCERTAINTY_MAXIMUM_REJECTED_SHARE {
+		return nil, fmt.Errorf("too many implausible samples in the tolerances of the inputs (%d of %d)", rejected, samples)
+	}
+	sort.Float64s(tccs)
+
+	return &Uncertainty{
+		Samples:  len(tccs),
+		Rejected: rejected,
+		Median:   evaluatePercentile(tccs, 50),
+		Lower:    evaluatePercentile(tccs, UNCERTAINTY_LOWER_PERCENTILE),
+		Upper:    evaluatePercentile(tccs, UNCERTAINTY_UPPER_PERCENTILE),
+	}, nil
+}
+
+// ValidateTolerances checks that every tolerance belongs to a numeric input and is inside of [0; 1).
+func ValidateTolerances(tolerances map[string]float64) error {
+	for name, tolerance := range tolerances {
+		if !slices.ContainsFunc(sensitivityInputs, func(s sensitivityInput) bool { return s.name == name }) {
+			return fmt.Errorf("unknown uncertainty input '%s'", name)
+		}
+		if math.IsNaN(tolerance) || tolerance < 0 || tolerance >= 1 {
+			return fmt.Errorf("invalid tolerance '%g' for input '%s'", tolerance, name)
+		}
+	}
+	return nil
+}
+
+// evaluatePercentile calcs the percentile of the sorted values with linear interpolation.
+func evaluatePercentile(sorted []float64, percentile float64) float64 {
+	position := (percentile / 100) * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(position-float64(lower))
+}
@@ -0,0 +1,113 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestValidateTolerances(t *testing.T) {
+	tests := []struct {
+		name       string
+		tolerances map[string]float64
+		message    string
+	}{
+		{"empty", nil, ""},
+		{"valid", map[string]float64{"wsa": 0.15, "composition.cfk": 0}, ""},
+		{"unknown_input", map[string]float64{"hull": 0.1}, "unknown uncertainty input 'hull'"},
+		{"negative", map[string]float64{"wsa": -0.1}, "invalid tolerance '-0.1' for input 'wsa'"},
+		{"too_large", map[string]float64{"wsa": 1}, "invalid tolerance '1' for input 'wsa'"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateTolerances(test.tolerances)
+			if test.message == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+			} else if err == nil || err.Error() != test.message {
+				t.Fatalf("expected error '%s', got %v", test.message, err)
+			}
+		})
+	}
+}
+
+func TestEvaluateUncertaintyBounds(t *testing.T) {
+	tolerances := map[string]float64{"wsa": 0.15, "displacement": 0.1}
+	uncertainty, err := EvaluateUncertainty(NewAlgorithm(nil), newTestDinghyInput(), tolerances, 1000, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(uncertainty.Lower < uncertainty.Median && uncertainty.Median < uncertainty.Upper) {
+		t.Fatalf("expected distinct bounds, got %+v", uncertainty)
+	}
+	// rounded points only produce TCCs in steps of 1/1800 (1/450 per speed point, 1/600 per stabilization or agility point).
+	for _, bound := range []float64{uncertainty.Lower, uncertainty.Median, uncertainty.Upper} {
+		steps := bound * 1800
+		if math.Abs(steps-math.Round(steps)) < 1e-6 {
+			t.Errorf("expected unrounded bound, got %v", bound)
+		}
+	}
+
+	repeated, err := EvaluateUncertainty(NewAlgorithm(nil), newTestDinghyInput(), tolerances, 1000, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *repeated != *uncertainty {
+		t.Fatalf("expected the same bounds for the same seed, got %+v and %+v", uncertainty, repeated)
+	}
+}
+
+func TestEvaluateUncertaintyRejected(t *testing.T) {
+	tests := []struct {
+		name      string
+		loa       float64
+		tolerance float64
+		message   string
+	}{
+		// a tolerance of ±11% puts ~5% of the sampled loas below the plausible minimum of 1 m.
+		{"few_rejected", 1.1, 0.11, ""},
+		{"too_many_rejected", 1.05, 0.5, "too many implausible samples in the tolerances of the inputs"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := newTestInput()
+			input.LOA = test.loa
+			uncertainty, err := EvaluateUncertainty(NewAlgorithm(nil), input, map[string]float64{"loa": test.tolerance}, 1000, 1)
+			if test.message != "" {
+				if err == nil || !strings.Contains(err.Error(), test.message) {
+					t.Fatalf("expected error '%s', got %v", test.message, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if uncertainty.Rejected < 1 || float64(uncertainty.Rejected) > 1000*UNCERTAINTY_MAXIMUM_REJECTED_SHARE {
+				t.Errorf("expected a few rejected samples, got %d", uncertainty.Rejected)
+			}
+			if uncertainty.Samples+uncertainty.Rejected != 1000 {
+				t.Errorf("expected every sample to be counted, got %d samples and %d rejected", uncertainty.Samples, uncertainty.Rejected)
+			}
+		})
+	}
+}
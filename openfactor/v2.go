@@ -0,0 +1,54 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import "maps"
+
+// VERSION_V2 specifies the algorithm version that evaluates the intended edge length ratios.
+const VERSION_V2 = "v0.0.2"
+
+// empirical constants used by the CalibrationV2.
+const (
+	V2_AREA_EXPONENT   = 1.0 / 2 // square root of an area
+	V2_VOLUME_EXPONENT = 1.0 / 3 // cube root of a volume
+
+	// with the real ratios the sail power scales with the sail area and the sail area / displacement ratio,
+	// the normalizer is therefore raised to keep the speed points inside of the ~1.0-2.0 scale.
+	V2_SAIL_POWER_NORMALIZER = 1000
+	// with the real ratios the stabilization impact includes the wsa and sail area / displacement ratios,
+	// the scale is therefore lowered to keep the stabilization points inside of the ~1.0-2.0 scale.
+	V2_STABILIZATION_POINT_SCALE = 0.2
+)
+
+// CalibrationV2 returns the built-in calibration of the v0.0.2 algorithm.
+// It equals the DefaultCalibration, except that the sail area, wsa and displacement ratios
+// are derived with the intended fractional exponents and that the points are weighted by the material composition.
+func CalibrationV2() *Calibration {
+	calibration := DefaultCalibration()
+	calibration.Version = VERSION_V2
+	calibration.AreaExponent = V2_AREA_EXPONENT
+	calibration.VolumeExponent = V2_VOLUME_EXPONENT
+	calibration.SailPowerNormalizer = V2_SAIL_POWER_NORMALIZER
+	calibration.StabilizationPointScale = V2_STABILIZATION_POINT_SCALE
+	calibration.MaterialStiffnessFactor = maps.Clone(MATERIAL_STIFFNESS_FACTOR)
+	calibration.MaterialDistributionFactor = maps.Clone(MATERIAL_DISTRIBUTION_FACTOR)
+	calibration.MaterialDragFactor = maps.Clone(MATERIAL_DRAG_FACTOR)
+	return calibration
+}
@@ -0,0 +1,55 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import "testing"
+
+func TestBuiltinCalibrationReproducesV1(t *testing.T) {
+	calibration, err := BuiltinCalibration("v0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// published v0.0.1 ratings of the register example ships.
+	tests := []struct {
+		name  string
+		input *EvaluationInput
+		tcc   float64
+	}{
+		{"gc32", newTestInput(), 0.835},
+		{"hobie", newTestDinghyInput(), 0.9116666666666665},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, err := EvaluateFactor(test.input, calibration)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertFloat(t, "tcc", output.TCC, test.tcc)
+
+			v2Output, err := EvaluateFactor(test.input, CalibrationV2())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v2Output.TCC == output.TCC {
+				t.Error("expected the v0.0.2 calibration to change the rating")
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+)
+
+// Range specifies a plausible value range (inclusive).
+type Range struct {
+	Min float64
+	Max float64
+}
+
+var PLAUSIBILITY_RANGES = map[string]Range{
+	"loa":                       {Min: 1, Max: 60},        // from small dinghies to large maxis
+	"max_draft":                 {Min: 0.05, Max: 8},      // from boards lifted to canting keels of large maxis
+	"max_beam":                  {Min: 0.5, Max: 30},      // from narrow skiffs to large trimarans
+	"imsl":                      {Min: 1, Max: 70},        // forestay height
+	"wsa":                       {Min: 0.5, Max: 500},     // wetted surface area
+	"main_sail_area":            {Min: 1, Max: 1500},      // every ship is expected to carry a main
+	"jib_sail_area":             {Min: 0, Max: 1500},      // catboats have no jib
+	"asymmetric_spinnaker_area": {Min: 0, Max: 3000},      // optional
+	"symmetric_spinnaker_area":  {Min: 0, Max: 3000},      // optional
+	"code_zero_area":            {Min: 0, Max: 3000},      // optional
+	"rig.bowsprit_length":       {Min: 0, Max: 15},        // optional
+	"rig.spreaders":             {Min: 0, Max: 6},         // optional
+	"displacement":              {Min: 20, Max: 250000},   // from dinghies to large maxis
+	"crew_weight":               {Min: 40, Max: 5000},     // at least one sailor
+	"crew.count":                {Min: 0, Max: 60},        // optional
+	"construction_year":         {Min: 1800, Max: 2200},   // optional (0 if unknown)
+	"composition":               {Min: 0, Max: 100.00001}, // percentage of the weight (with rounding tolerance)
+}
+
+// RangeError indicates that an input is outside of its plausible range.
+type RangeError struct {
+	Input string
+	Value float64
+	Range Range
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("input '%s' (%g) is outside of the plausible range [%g; %g]",
+		e.Input, e.Value, e.Range.Min, e.Range.Max,
+	)
+}
+
+// ConflictError indicates that an input must differ from another input.
+type ConflictError struct {
+	Input          string
+	Value          float64
+	Reference      string
+	ReferenceValue float64
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("input '%s' (%g) must differ from input '%s' (%g)",
+		e.Input, e.Value, e.Reference, e.ReferenceValue,
+	)
+}
+
+// NonFiniteError indicates that an input or a result of the evaluation is NaN or infinite.
+type NonFiniteError struct {
+	Name  string
+	Value float64
+}
+
+func (e *NonFiniteError) Error() string {
+	return fmt.Sprintf("'%s' is not a finite number (%g)", e.Name, e.Value)
+}
+
+// UnknownEnumError indicates an enum value that is not known to the calibration.
+type UnknownEnumError struct {
+	Enum  string
+	Value int64
+	// WindBand specifies the wind band whose table misses the value (empty for the base tables).
+	WindBand string
+}
+
+func (e *UnknownEnumError) Error() string {
+	if e.WindBand != "" {
+		return fmt.Sprintf("unknown %s '%d' in wind band '%s'", e.Enum, e.Value, e.WindBand)
+	}
+	return fmt.Sprintf("unknown %s '%d'", e.Enum, e.Value)
+}
+
+// ValidateInput checks that the input can be evaluated with the calibration.
+// If no calibration is provided, the DefaultCalibration is used.
+func ValidateInput(input *EvaluationInput, calibration *Calibration) error {
+	if calibration == nil {
+		calibration = DefaultCalibration()
+	}
+
+	dimensions := []struct {
+		name  string
+		value float64
+	}{
+		{"loa", input.LOA},
+		{"max_draft", input.MaxDraft},
+		{"max_beam", input.MaxBeam},
+		{"imsl", input.IMSL},
+		{"wsa", input.WSA},
+		{"main_sail_area", input.MainSailArea},
+		{"jib_sail_area", input.JibSailArea},
+		{"asymmetric_spinnaker_area", input.AsymmetricSpinnakerArea},
+		{"symmetric_spinnaker_area", input.SymmetricSpinnakerArea},
+		{"code_zero_area", input.CodeZeroArea},
+		{"rig.bowsprit_length", input.Rig.BowspritLength},
+		{"rig.spreaders", float64(input.Rig.Spreaders)},
+		{"displacement", input.Displacement},
+		{"crew_weight", input.CrewWeight},
+		{"crew.count", float64(input.Crew.Count)},
+	}
+	for _, dimension := range dimensions {
+		if err := validateRange(dimension.name, dimension.value, PLAUSIBILITY_RANGES[dimension.name]); err != nil {
+			return err
+		}
+	}
+	if input.ConstructionYear != 0 {
+		if err := validateRange("construction_year", float64(input.ConstructionYear), PLAUSIBILITY_RANGES["construction_year"]); err != nil {
+			return err
+		}
+	}
+	// the beam must differ from the loa, otherwise the beam / loa ratio is undefined (see evaluateAgilityPoints).
+	if input.MaxBeam == input.LOA {
+		return &ConflictError{
+			Input:          "max_beam",
+			Value:          input.MaxBeam,
+			Reference:      "loa",
+			ReferenceValue: input.LOA,
+		}
+	}
+
+	totalComposition := 0.0
+	for _, material := range slices.Sorted(maps.Keys(input.Composition)) {
+		percentage := input.Composition[material]
+		if _, ok := MATERIAL_NAMES[material]; !ok {
+			return &UnknownEnumError{Enum: "material", Value: int64(material)}
+		}
+		if err := validateRange("composition."+material.String(), percentage, PLAUSIBILITY_RANGES["composition"]); err != nil {
+			return err
+		}
+		totalComposition += percentage
+	}
+	if err := validateRange("composition", totalComposition, PLAUSIBILITY_RANGES["composition"]); err != nil {
+		return err
+	}
+
+	if _, ok := calibration.ModeDragFactor[input.Mode]; !ok {
+		return &UnknownEnumError{Enum: "mode", Value: int64(input.Mode)}
+	}
+	// every wind band evaluates the drag with its own table (see windBandCalibration).
+	for _, band := range calibration.WindBands {
+		if _, ok := band.ModeDragFactor[input.Mode]; !ok {
+			return &UnknownEnumError{Enum: "mode", Value: int64(input.Mode), WindBand: band.Name}
+		}
+	}
+	_, stabilizationOk := calibration.StabilizationStabilizationFactor[input.Stabilization]
+	_, agilityOk := calibration.StabilizationAgilityFactor[input.Stabilization]
+	if !stabilizationOk || !agilityOk {
+		return &UnknownEnumError{Enum: "stabilization", Value: int64(input.Stabilization)}
+	}
+	_, stabilizationOk = calibration.HullStabilizationFactor[input.Hull]
+	_, agilityOk = calibration.HullAgilityFactor[input.Hull]
+	if !stabilizationOk || !agilityOk {
+		return &UnknownEnumError{Enum: "hull", Value: int64(input.Hull)}
+	}
+
+	_, upwindOk := calibration.RigUpwindFactor[input.Rig.Type]
+	_, downwindOk := calibration.RigDownwindFactor[input.Rig.Type]
+	if !upwindOk || !downwindOk {
+		return &UnknownEnumError{Enum: "rig", Value: int64(input.Rig.Type)}
+	}
+
+	return nil
+}
+
+// validateRange checks that the value is finite and inside of the range.
+func validateRange(name string, value float64, valueRange Range) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return &NonFiniteError{Name: name, Value: value}
+	}
+	if value < valueRange.Min || value > valueRange.Max {
+		return &RangeError{Input: name, Value: value, Range: valueRange}
+	}
+	return nil
+}
+
+// validateOutput checks that the evaluation produced finite results.
+func validateOutput(output *EvaluationOutput) error {
+	results := []struct {
+		name  string
+		value float64
+	}{
+		{"tcc", output.TCC},
+		{"speed_factor", output.SpeedFactor},
+		{"stabilization_factor", output.StabilizationFactor},
+		{"agility_factor", output.AgilityFactor},
+		{"seconds_per_mile", output.SecondsPerMile},
+		{"plt", output.PLT},
+		{"pld", output.PLD},
+	}
+	for _, band := range output.WindBands {
+		results = append(results, struct {
+			name  string
+			value float64
+		}{"wind_band." + band.Name + ".tcc", band.TCC})
+	}
+	for _, course := range output.Courses {
+		results = append(results, struct {
+			name  string
+			value float64
+		}{"course." + course.Name + ".tcc", course.TCC})
+	}
+
+	for _, result := range results {
+		if math.IsNaN(result.value) || math.IsInf(result.value, 0) {
+			return &NonFiniteError{Name: result.name, Value: result.value}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestValidateInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(input *EvaluationInput, calibration *Calibration)
+		message string
+	}{
+		{"valid", func(input *EvaluationInput, calibration *Calibration) {}, ""},
+		// square multihulls are rated with the symmetric beam / loa ratio.
+		{"beam_wider_than_loa", func(input *EvaluationInput, calibration *Calibration) { input.MaxBeam = 11 }, ""},
+		{"loa_too_short", func(input *EvaluationInput, calibration *Calibration) { input.LOA = 0.5 },
+			"input 'loa' (0.5) is outside of the plausible range [1; 60]"},
+		{"total_composition", func(input *EvaluationInput, calibration *Calibration) { input.Composition[MATERIAL_WOOD] = 10 },
+			"input 'composition' (110) is outside of the plausible range [0; 100.00001]"},
+		{"nan_displacement", func(input *EvaluationInput, calibration *Calibration) { input.Displacement = math.NaN() },
+			"'displacement' is not a finite number (NaN)"},
+		{"beam_equals_loa", func(input *EvaluationInput, calibration *Calibration) { input.MaxBeam = 10 },
+			"input 'max_beam' (10) must differ from input 'loa' (10)"},
+		{"unknown_material", func(input *EvaluationInput, calibration *Calibration) { input.Composition[MATERIAL(42)] = 0 },
+			"unknown material '42'"},
+		{"unknown_mode", func(input *EvaluationInput, calibration *Calibration) { input.Mode = MODE(42) },
+			"unknown mode '42'"},
+		{"unknown_wind_band_mode", func(input *EvaluationInput, calibration *Calibration) {
+			delete(calibration.WindBands[1].ModeDragFactor, MODE_HYDROFOIL)
+		}, "unknown mode '1' in wind band 'medium'"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input, calibration := newTestInput(), DefaultCalibration()
+			test.modify(input, calibration)
+			err := ValidateInput(input, calibration)
+			if test.message == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+			} else if err == nil || err.Error() != test.message {
+				t.Fatalf("expected error '%s', got %v", test.message, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import "maps"
+
+// WindBand specifies a true wind range in which the boats are rated with a dedicated drag and stabilization weighting.
+type WindBand struct {
+	// Name specifies the wind band identifier (e.g. 'light').
+	Name string
+	// MinWindSpeed specifies the lower bound of the true wind speed in knots.
+	MinWindSpeed float64
+	// MaxWindSpeed specifies the upper bound of the true wind speed in knots.
+	MaxWindSpeed float64
+
+	// ModeDragFactor replaces the calibration MODE_DRAG_FACTOR in this wind band.
+	ModeDragFactor map[MODE]float64
+	// StabilizationFactorInfluence replaces the calibration STABILIZATION_FACTOR_INFLUENCE in this wind band.
+	StabilizationFactorInfluence float64
+}
+
+var WIND_BANDS = []WindBand{
+	{
+		Name:         "light",
+		MinWindSpeed: 0,
+		MaxWindSpeed: 8,
+		ModeDragFactor: map[MODE]float64{
+			MODE_DEFAULT:   0,   // default mode is considered 0 drag - boat does not touch the water.
+			MODE_HYDROFOIL: 1,   // hydrofoils do not lift off in light air and drag the foils through the water.
+			MODE_PLANING:   1,   // planing boats do not plane in light air.
+			MODE_SEMI:      1,   // semi planing boats do not plane in light air.
+			MODE_DISPLACE:  1.1, // displacement boats are generally heavier and struggle to get going in light air.
+		},
+		StabilizationFactorInfluence: 0.25, // heeling forces are small, stabilization barely matters.
+	},
+	{
+		Name:         "medium",
+		MinWindSpeed: 8,
+		MaxWindSpeed: 16,
+		ModeDragFactor: map[MODE]float64{
+			MODE_DEFAULT:   0,   // default mode is considered 0 drag - boat does not touch the water.
+			MODE_HYDROFOIL: 0.3, // hydrofoils lift off in most of the range.
+			MODE_PLANING:   0.7, // planing boats start planing in the upper range.
+			MODE_SEMI:      0.9, // semi planing boats rarely plane in this range.
+			MODE_DISPLACE:  1,   // boat has 100% wsa as it is not planing.
+		},
+		StabilizationFactorInfluence: 0.5, // stabilization is weighted as in the overall rating.
+	},
+	{
+		Name:         "heavy",
+		MinWindSpeed: 16,
+		MaxWindSpeed: 30,
+		ModeDragFactor: map[MODE]float64{
+			MODE_DEFAULT:   0,    // default mode is considered 0 drag - boat does not touch the water.
+			MODE_HYDROFOIL: 0.05, // hydrofoils provide optimal drag (nearly 0).
+			MODE_PLANING:   0.5,  // planing boats plane most of the time.
+			MODE_SEMI:      0.7,  // semi planing boats plane in gusts.
+			MODE_DISPLACE:  0.95, // displacement boats profit from waves and their waterline length.
+		},
+		StabilizationFactorInfluence: 1, // heeling forces are strong, stabilization decides how much power can be used.
+	},
+}
+
+// cloneWindBands creates a deep copy of the wind bands.
+func cloneWindBands(bands []WindBand) []WindBand {
+	clones := []WindBand{}
+	for _, band := range bands {
+		band.ModeDragFactor = maps.Clone(band.ModeDragFactor)
+		clones = append(clones, band)
+	}
+	return clones
+}
+
+// windBandCalibration returns a copy of the calibration with the drag and stabilization weighting of the wind band.
+func (c *Calibration) windBandCalibration(band WindBand) *Calibration {
+	bandCalibration := *c
+	bandCalibration.ModeDragFactor = band.ModeDragFactor
+	bandCalibration.StabilizationFactorInfluence = band.StabilizationFactorInfluence
+	bandCalibration.WindBands = nil
+	bandCalibration.Courses = nil
+	return &bandCalibration
+}
@@ -0,0 +1,74 @@
+/**
+ * Opensail System
+ *
+ * Copyright (C) 2024 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package openfactor
+
+import "testing"
+
+func TestEvaluateFactorWindBands(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *EvaluationInput
+		tccs  map[string]float64
+	}{
+		{"gc32", newTestInput(), map[string]float64{
+			"light":  0.8752777777777778,
+			"medium": 0.8661111111111112,
+			"heavy":  0.9899999999999999,
+		}},
+		{"hobie", newTestDinghyInput(), map[string]float64{
+			"light":  0.8366666666666666,
+			"medium": 0.9183333333333333,
+			"heavy":  1.0816666666666668,
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, err := EvaluateFactor(test.input, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(output.WindBands) != len(test.tccs) {
+				t.Fatalf("expected %d wind bands, got %d", len(test.tccs), len(output.WindBands))
+			}
+			for _, band := range output.WindBands {
+				assertFloat(t, band.Name+" tcc", band.TCC, test.tccs[band.Name])
+			}
+		})
+	}
+}
+
+func TestWindBandCalibration(t *testing.T) {
+	c := DefaultCalibration()
+	band := c.WindBands[2]
+	bandCalibration := c.windBandCalibration(band)
+
+	if bandCalibration.StabilizationFactorInfluence != 1 {
+		t.Errorf("expected the stabilization influence of the band, got %v", bandCalibration.StabilizationFactorInfluence)
+	}
+	if bandCalibration.ModeDragFactor[MODE_PLANING] != 0.5 {
+		t.Errorf("expected the drag factor of the band, got %v", bandCalibration.ModeDragFactor[MODE_PLANING])
+	}
+	if bandCalibration.WindBands != nil || bandCalibration.Courses != nil {
+		t.Error("expected the band calibration to contain no wind bands and courses")
+	}
+	if c.StabilizationFactorInfluence != STABILIZATION_FACTOR_INFLUENCE || len(c.WindBands) != len(WIND_BANDS) {
+		t.Error("expected the base calibration to be unchanged")
+	}
+}
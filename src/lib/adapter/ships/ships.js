@@ -11,6 +11,29 @@ import { AdapterError } from "$lib/adapter/error";
  * @property {ShipConfigBaseSpec} boat_base_spec
  * @property {ShipConfigExtraSpec} boat_extra_spec
  * @property {ShipConfigRating} boat_rating
+ * @property {Object.<string, ShipConfigRating>} boat_ratings
+ * @property {Object.<string, ShipConfigSailConfiguration>} boat_sail_configurations
+ */
+
+/**
+ * @typedef {Object} ShipConfigSailConfiguration
+ * @property {ShipConfigBaseSpecSailArea} sail_area
+ * @property {ShipConfigRating} rating
+ */
+
+/**
+ * @typedef {Object} ShipConfigConfidence
+ * @property {number} score
+ * @property {boolean} review
+ * @property {ShipConfigConfidenceFinding[]} findings
+ */
+
+/**
+ * @typedef {Object} ShipConfigConfidenceFinding
+ * @property {string} check
+ * @property {string} field
+ * @property {string} message
+ * @property {number} penalty
  */
 
 /**
@@ -28,6 +51,8 @@ import { AdapterError } from "$lib/adapter/error";
  * @property {string} source
  * @property {ShipConfigBaseSpecDimension} dimension
  * @property {ShipConfigBaseSpecSailArea} sail_area
+ * @property {ShipConfigBaseSpecRig} rig
+ * @property {Object.<string, number>} tolerance
  */
 
 /**
@@ -47,6 +72,15 @@ import { AdapterError } from "$lib/adapter/error";
  * @property {number} jib
  * @property {number} asymmetric_spinnaker
  * @property {number} symmetric_spinnaker
+ * @property {number} code_zero
+ */
+
+/**
+ * @typedef {Object} ShipConfigBaseSpecRig
+ * @property {string} type
+ * @property {number} bowsprit_length
+ * @property {number} spreaders
+ * @property {boolean} furling_main
  */
 
 /**
@@ -83,12 +117,67 @@ import { AdapterError } from "$lib/adapter/error";
  * @property {number} speed_drag_points
  * @property {number} speed_upwind_points
  * @property {number} speed_downwind_points
+ * @property {number} speed_reach_points
  * @property {number} stabilization_factor
  * @property {number} stabilization_influence
  * @property {number} stabilization_points
  * @property {number} agility_factor
  * @property {number} agility_influence
  * @property {number} agility_points
+ * @property {number} age_allowance
+ * @property {number} seconds_per_mile
+ * @property {number} plt
+ * @property {number} pld
+ * @property {ShipConfigRatingWindBand[]} wind_bands
+ * @property {ShipConfigRatingCourse[]} courses
+ * @property {ShipConfigRatingShortHanded[]} short_handed
+ * @property {ShipConfigConfidence} confidence
+ * @property {?ShipConfigRatingUncertainty} uncertainty
+ * @property {ShipConfigRatingTrace[]} trace
+ */
+
+/**
+ * @typedef {Object} ShipConfigRatingWindBand
+ * @property {string} name
+ * @property {number} min_wind_speed
+ * @property {number} max_wind_speed
+ * @property {number} tcc
+ */
+
+/**
+ * @typedef {Object} ShipConfigRatingCourse
+ * @property {string} name
+ * @property {number} upwind
+ * @property {number} reach
+ * @property {number} downwind
+ * @property {number} tcc
+ */
+
+/**
+ * @typedef {Object} ShipConfigRatingShortHanded
+ * @property {number} crew_count
+ * @property {boolean} autopilot
+ * @property {number} tcc
+ * @property {number} speed_factor
+ * @property {number} agility_factor
+ */
+
+/**
+ * @typedef {Object} ShipConfigRatingUncertainty
+ * @property {number} samples
+ * @property {number} rejected
+ * @property {number} median
+ * @property {number} lower
+ * @property {number} upper
+ * @property {number} lower_percentile
+ * @property {number} upper_percentile
+ */
+
+/**
+ * @typedef {Object} ShipConfigRatingTrace
+ * @property {string} category
+ * @property {string} term
+ * @property {number} value
  */
 
 /**